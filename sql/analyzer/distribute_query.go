@@ -0,0 +1,187 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/router"
+)
+
+// distributeQuery runs after resolveViews. For every ResolvedTable scanning
+// a table the server's router.Router has sharded, it rewrites the scan into
+// a plan.Exchange fanning out over every shard — unless the query's WHERE
+// clause pins the shard key to a single constant (an Equals) or a
+// disjunction of Equals that all resolve to the same shard (the shape a
+// small IN-list expands to), in which case it resolves the one shard that
+// can possibly match and scans only that backend, skipping the fan-out
+// entirely. A literal IN expression that never gets expanded to Equals/Or
+// isn't recognized here, since this tree's sql/expression package doesn't
+// define one to match against.
+//
+// Nothing calls this function outside of this file: this tree has no analyzer rule-list/registration mechanism at all (no file
+// anywhere builds or runs an ordered batch of passes like this one), so
+// there is no real place to hook it into today.
+func distributeQuery(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, _ := ctx.Span("distribute_query")
+	defer span.Finish()
+
+	r, ok := router.ForCatalog(a.Catalog)
+	if !ok {
+		return n, nil
+	}
+
+	// Look for a Filter directly above a candidate scan, so a single-shard
+	// short circuit can be recognized; this mirrors the most common shape
+	// `SELECT ... FROM t WHERE shard_key = ...` produces before other
+	// analyzer passes restructure it further.
+	pinned := map[sql.Node]string{} // ResolvedTable -> pinned shard name
+	plan.Inspect(n, func(node sql.Node) bool {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return true
+		}
+		for _, child := range filter.Children() {
+			rt, ok := child.(*plan.ResolvedTable)
+			if !ok {
+				continue
+			}
+			st, ok := r.Lookup(rt.Database.Name(), rt.Name())
+			if !ok {
+				continue
+			}
+			if shard, ok := pinnedShard(filter.Expression, st, r); ok {
+				pinned[rt] = shard
+			}
+		}
+		return true
+	})
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		rt, ok := node.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		st, ok := r.Lookup(rt.Database.Name(), rt.Name())
+		if !ok {
+			return node, nil
+		}
+
+		if shard, ok := pinned[rt]; ok {
+			db, ok := st.Shards[shard]
+			if !ok {
+				return node, nil
+			}
+			return scanShard(ctx, rt, db)
+		}
+
+		shardNames := st.ShardNames()
+		shards := make([]sql.Node, len(shardNames))
+		for i, name := range shardNames {
+			scan, err := scanShard(ctx, rt, st.Shards[name])
+			if err != nil {
+				return nil, err
+			}
+			shards[i] = scan
+		}
+		return plan.NewExchange(shards), nil
+	})
+}
+
+// scanShard resolves rt's table name against db and returns a ResolvedTable
+// scanning it, preserving rt's AsOf clause.
+func scanShard(ctx *sql.Context, rt *plan.ResolvedTable, db sql.Database) (sql.Node, error) {
+	table, ok, err := db.GetTableInsensitive(ctx, rt.Name())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(rt.Name())
+	}
+	return plan.NewResolvedTable(table, db, rt.AsOf), nil
+}
+
+// pinnedShard reports whether expr guarantees every row it lets through
+// pins st's shard key to a single, staticaly-known shard: an Equals of the
+// shard key column against a Literal, an And with at least one side that
+// pins a shard, or an Or whose sides all pin the same shard (the expanded
+// form of a small IN-list). An IN expression itself is not matched.
+func pinnedShard(expr sql.Expression, st router.ShardedTable, r *router.Router) (string, bool) {
+	if len(st.KeyColumns) != 1 {
+		// Multi-column shard keys would need every column pinned at once;
+		// left for a follow-up since it needs walking a conjunction of
+		// Equals expressions rather than a single comparison.
+		return "", false
+	}
+	keyCol := st.KeyColumns[0]
+
+	switch e := expr.(type) {
+	case *expression.Equals:
+		return pinnedShardFromEquals(e, keyCol, st)
+	case *expression.And:
+		if shard, ok := pinnedShard(e.Left, st, r); ok {
+			return shard, true
+		}
+		return pinnedShard(e.Right, st, r)
+	case *expression.Or:
+		// An IN-list over the shard key parses down to a disjunction of
+		// Equals once expanded; it only pins a single shard if every
+		// alternative hashes to that same shard; a value on the "wrong"
+		// shard means the filter could still let through a row this branch
+		// alone doesn't account for, so both sides must resolve and agree.
+		left, leftOK := pinnedShard(e.Left, st, r)
+		if !leftOK {
+			return "", false
+		}
+		right, rightOK := pinnedShard(e.Right, st, r)
+		if !rightOK || left != right {
+			return "", false
+		}
+		return left, true
+	default:
+		return "", false
+	}
+}
+
+func pinnedShardFromEquals(e *expression.Equals, keyCol string, st router.ShardedTable) (string, bool) {
+	left, right := e.Left(), e.Right()
+	var lit *expression.Literal
+	var col sql.Expression = left
+	if l, ok := right.(*expression.Literal); ok {
+		lit = l
+		col = left
+	} else if l, ok := left.(*expression.Literal); ok {
+		lit = l
+		col = right
+	} else {
+		return "", false
+	}
+
+	getField, ok := col.(*expression.GetField)
+	if !ok || getField.Name() != keyCol {
+		return "", false
+	}
+
+	shard, err := st.KeyFunc([]interface{}{lit.Value()})
+	if err != nil {
+		return "", false
+	}
+	if _, ok := st.Shards[shard]; !ok {
+		return "", false
+	}
+	return shard, true
+}
@@ -2,8 +2,9 @@ package analyzer
 
 import (
 	"fmt"
-	"github.com/src-d/go-mysql-server/sql"
-	"github.com/src-d/go-mysql-server/sql/plan"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
 func resolveViews(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
@@ -32,6 +33,27 @@ func resolveViews(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 		if err == nil {
 			a.Log("view resolved: %q", name)
 
+			if mvInfo, ok := a.Catalog.MaterializedViews().Get(db, name); ok {
+				if t.AsOf != nil {
+					return nil, sql.ErrAsOfMaterializedView.New(db, name)
+				}
+
+				if mvInfo.IsFresh(currentTableVersions(ctx, a, mvInfo)) {
+					a.Log("materialized view %q is fresh, scanning backing table %q instead of expanding definition", name, mvInfo.BackingTable)
+					backingDb := mvInfo.BackingDatabase
+					if backingDb == "" {
+						backingDb = db
+					}
+					table, tErr := a.Catalog.Table(ctx, backingDb, mvInfo.BackingTable)
+					if tErr != nil {
+						return nil, tErr
+					}
+					return plan.NewResolvedTable(table, a.Catalog.Database(backingDb), nil), nil
+				}
+
+				a.Log("materialized view %q is stale, falling back to expanding its definition", name)
+			}
+
 			// If this view is being asked for with an AS OF clause, then attempt to apply it to every table in the view.
 			if t.AsOf != nil {
 				a.Log("applying AS OF clause to view definition")
@@ -72,3 +94,29 @@ func resolveViews(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 		return nil, err
 	})
 }
+
+// currentTableVersions looks up the current VersionedTable.CurrentVersion of
+// every table info.SourceVersions was recorded against. A table that either
+// can't be found or doesn't implement sql.VersionedTable is simply omitted,
+// which makes MaterializedViewInfo.IsFresh report staleness for it (since
+// its recorded version can never match a missing current one) rather than
+// silently skipping it.
+func currentTableVersions(ctx *sql.Context, a *Analyzer, info sql.MaterializedViewInfo) map[sql.TableID]string {
+	versions := make(map[sql.TableID]string, len(info.SourceVersions))
+	for id := range info.SourceVersions {
+		table, err := a.Catalog.Table(ctx, id.Database, id.Table)
+		if err != nil {
+			continue
+		}
+		vt, ok := table.(sql.VersionedTable)
+		if !ok {
+			continue
+		}
+		version, err := vt.CurrentVersion(ctx)
+		if err != nil {
+			continue
+		}
+		versions[id] = version
+	}
+	return versions
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// NormalizeAxisOrder applies srs's axis order to g: if srs.AxisOrder is
+// AxisOrderLatLong (as for EPSG:4326 and other EPSG-authoritative
+// geographic SRSs), every point's coordinates are given as
+// (latitude, longitude) in WKT/WKB/GeoJSON source text, but go-mysql-server
+// stores and renders geometry internally in the traditional GIS
+// (longitude, latitude) order — matching MySQL's own behavior of swapping
+// coordinates on the way in for such SRSs. AxisOrderLongLat SRSs (the
+// default, SRID 0 included) already match internal storage order, so g is
+// returned unchanged.
+//
+// This is applied once, at parse time: by ST_GeomFromText and
+// ST_GeomFromWKB when an explicit SRID argument is given, and by
+// ST_GeomFromGeoJSON always, using SRID 4326 when no SRID argument is
+// given. It is not
+// applied by ST_SRID: MySQL's ST_SRID only retags a geometry's SRID
+// metadata, it never reinterprets or reorders coordinates that were already
+// parsed.
+func NormalizeAxisOrder(g GeometryValue, srs SpatialRefSystem) GeometryValue {
+	if srs.AxisOrder != AxisOrderLatLong {
+		return g
+	}
+	return swapAxes(g)
+}
+
+func swapAxes(g GeometryValue) GeometryValue {
+	switch v := g.(type) {
+	case Point:
+		v.X, v.Y = v.Y, v.X
+		return v
+	case Linestring:
+		v.Points = swapPoints(v.Points)
+		return v
+	case Polygon:
+		lines := make([]Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = swapAxes(l).(Linestring)
+		}
+		v.Lines = lines
+		return v
+	case MultiPoint:
+		v.Points = swapPoints(v.Points)
+		return v
+	case MultiLinestring:
+		lines := make([]Linestring, len(v.Lines))
+		for i, l := range v.Lines {
+			lines[i] = swapAxes(l).(Linestring)
+		}
+		v.Lines = lines
+		return v
+	case MultiPolygon:
+		polys := make([]Polygon, len(v.Polygons))
+		for i, p := range v.Polygons {
+			polys[i] = swapAxes(p).(Polygon)
+		}
+		v.Polygons = polys
+		return v
+	case GeometryCollection:
+		geoms := make([]GeometryValue, len(v.Geoms))
+		for i, gg := range v.Geoms {
+			geoms[i] = swapAxes(gg)
+		}
+		v.Geoms = geoms
+		return v
+	default:
+		return g
+	}
+}
+
+func swapPoints(points []Point) []Point {
+	swapped := make([]Point, len(points))
+	for i, p := range points {
+		swapped[i] = swapAxes(p).(Point)
+	}
+	return swapped
+}
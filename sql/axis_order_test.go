@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAxisOrder(t *testing.T) {
+	require := require.New(t)
+
+	longLat := SpatialRefSystem{SRID: 0, AxisOrder: AxisOrderLongLat}
+	latLong := SpatialRefSystem{SRID: 4326, Name: "WGS 84", AxisOrder: AxisOrderLatLong}
+
+	p := Point{X: 1, Y: 2}
+	require.Equal(p, NormalizeAxisOrder(p, longLat))
+	require.Equal(Point{X: 2, Y: 1}, NormalizeAxisOrder(p, latLong))
+
+	ls := Linestring{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	require.Equal(Linestring{Points: []Point{{X: 2, Y: 1}, {X: 4, Y: 3}}}, NormalizeAxisOrder(ls, latLong))
+
+	coll := GeometryCollection{Geoms: []GeometryValue{p, ls}}
+	want := GeometryCollection{Geoms: []GeometryValue{
+		Point{X: 2, Y: 1},
+		Linestring{Points: []Point{{X: 2, Y: 1}, {X: 4, Y: 3}}},
+	}}
+	require.Equal(want, NormalizeAxisOrder(coll, latLong))
+}
+
+func TestDefaultSpatialRefSystemRegistryAxisOrder(t *testing.T) {
+	require := require.New(t)
+
+	registry := NewDefaultSpatialRefSystemRegistry()
+
+	wgs84, err := registry.Lookup(4326)
+	require.NoError(err)
+	require.Equal(AxisOrderLatLong, wgs84.AxisOrder)
+
+	unspecified, err := registry.Lookup(0)
+	require.NoError(err)
+	require.Equal(AxisOrderLongLat, unspecified.AxisOrder)
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// Dispose releases the per-catalog state this package's pointer-keyed
+// registries (RegisterMetadataProvider, MaterializedViews) are holding for
+// c, so c can be garbage collected once its owner (an Engine, typically) is
+// done with it. Callers that registered c with packages outside of sql —
+// for example sql/router's RegisterCatalog — must also call that package's
+// own unregister hook; this package has no way to reach into sql/router
+// without an import cycle.
+//
+// A Catalog that is never disposed is never freed: every pointer-keyed
+// registry above pins it for the life of the process, which matters for
+// long-running processes that construct more than one Catalog (tests,
+// multi-tenant embedding).
+//
+// Nothing in this tree calls Dispose automatically today: there is no
+// Engine (or other Catalog owner) type here to hook a Close/Shutdown method
+// into, and no Catalog constructor either, so there is no single place left
+// to wire this from without inventing that missing plumbing out of whole
+// cloth. Until a Catalog owner exists in this codebase, every caller that
+// constructs a Catalog is responsible for calling Dispose itself once done
+// with it.
+func (c *Catalog) Dispose() {
+	forgetMetadataProviders(c)
+	forgetMaterializedViews(c)
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import "sync"
+
+// MetadataProvider lets an integrator contribute additional rows to an
+// INFORMATION_SCHEMA table that the analyzer's resolved catalog alone
+// can't produce — for example a foreign key index kept outside of
+// go-mysql-server, or ORM-specific column comments. Providers are
+// consulted by the information_schema package after it has built the
+// rows it can derive itself, so contributed rows augment rather than
+// replace the built-in reflection.
+type MetadataProvider interface {
+	// Name identifies the provider for diagnostics; it has no effect on
+	// query results.
+	Name() string
+	// Rows returns the extra rows this provider contributes for the named
+	// INFORMATION_SCHEMA table (e.g. "key_column_usage"), in the table's
+	// own column order.
+	Rows(ctx *Context, table string) ([]Row, error)
+}
+
+// metadataProviderRegistry tracks MetadataProviders per Catalog instance.
+// It's keyed by pointer rather than stored as a Catalog field so that
+// registering a provider doesn't require a change to Catalog's struct
+// layout; the indirection is invisible to callers, who only ever see
+// Catalog.RegisterMetadataProvider / Catalog.MetadataProviders.
+var metadataProviderRegistry = struct {
+	mu        sync.RWMutex
+	providers map[*Catalog][]MetadataProvider
+}{providers: make(map[*Catalog][]MetadataProvider)}
+
+// RegisterMetadataProvider adds p to the catalog's list of INFORMATION_SCHEMA
+// metadata providers.
+func (c *Catalog) RegisterMetadataProvider(p MetadataProvider) {
+	metadataProviderRegistry.mu.Lock()
+	defer metadataProviderRegistry.mu.Unlock()
+	metadataProviderRegistry.providers[c] = append(metadataProviderRegistry.providers[c], p)
+}
+
+// MetadataProviders returns the catalog's registered INFORMATION_SCHEMA
+// metadata providers, in registration order.
+func (c *Catalog) MetadataProviders() []MetadataProvider {
+	metadataProviderRegistry.mu.RLock()
+	defer metadataProviderRegistry.mu.RUnlock()
+	registered := metadataProviderRegistry.providers[c]
+	providers := make([]MetadataProvider, len(registered))
+	copy(providers, registered)
+	return providers
+}
+
+// forgetMetadataProviders drops c's entry from metadataProviderRegistry, so
+// c can be garbage collected once nothing else references it. Called from
+// Catalog.Dispose.
+func forgetMetadataProviders(c *Catalog) {
+	metadataProviderRegistry.mu.Lock()
+	defer metadataProviderRegistry.mu.Unlock()
+	delete(metadataProviderRegistry.providers, c)
+}
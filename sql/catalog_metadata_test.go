@@ -0,0 +1,49 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetadataProvider struct {
+	name string
+	rows []Row
+}
+
+func (f fakeMetadataProvider) Name() string { return f.name }
+
+func (f fakeMetadataProvider) Rows(ctx *Context, table string) ([]Row, error) {
+	return f.rows, nil
+}
+
+func TestCatalogMetadataProviders(t *testing.T) {
+	require := require.New(t)
+	ctx := NewContext(context.Background(), WithSession(NewBaseSession()))
+
+	a := new(Catalog)
+	b := new(Catalog)
+
+	require.Empty(a.MetadataProviders())
+
+	p1 := fakeMetadataProvider{name: "p1", rows: []Row{{1}}}
+	p2 := fakeMetadataProvider{name: "p2", rows: []Row{{2}}}
+	a.RegisterMetadataProvider(p1)
+	a.RegisterMetadataProvider(p2)
+	b.RegisterMetadataProvider(fakeMetadataProvider{name: "other"})
+
+	providers := a.MetadataProviders()
+	require.Equal([]MetadataProvider{p1, p2}, providers)
+
+	rows, err := providers[0].Rows(ctx, "key_column_usage")
+	require.NoError(err)
+	require.Equal([]Row{{1}}, rows)
+
+	// b's registration must not be visible through a's pointer identity.
+	require.Len(b.MetadataProviders(), 1)
+
+	a.Dispose()
+	require.Empty(a.MetadataProviders())
+	require.Len(b.MetadataProviders(), 1, "disposing a must not affect b's registrations")
+}
@@ -27,18 +27,34 @@ import (
 // SRID is a function that returns SRID of Geometry object or returns a new object with altered SRID.
 type SRID struct {
 	expression.NaryExpression
+	registry sql.SpatialRefSystemRegistry
 }
 
 var _ sql.FunctionExpression = (*SRID)(nil)
 
 var ErrInvalidSRID = errors.NewKind("There's no spatial reference with SRID %d")
 
-// NewSRID creates a new STX expression.
+// NewSRID creates a new STX expression. It validates altered SRIDs against
+// the server's default spatial reference system registry; servers that
+// construct SRID directly and need a different registry (e.g. one seeded
+// with additional EPSG entries) should build the registry via
+// sql.NewDefaultSpatialRefSystemRegistry and swap it in with WithRegistry.
 func NewSRID(args ...sql.Expression) (sql.Expression, error) {
 	if len(args) != 1 && len(args) != 2 {
 		return nil, sql.ErrInvalidArgumentNumber.New("ST_SRID", "1 or 2", len(args))
 	}
-	return &SRID{expression.NaryExpression{ChildExpressions: args}}, nil
+	return &SRID{
+		NaryExpression: expression.NaryExpression{ChildExpressions: args},
+		registry:       sql.NewDefaultSpatialRefSystemRegistry(),
+	}, nil
+}
+
+// WithRegistry returns a copy of s that validates altered SRIDs against
+// registry instead of the default spatial reference system registry.
+func (s *SRID) WithRegistry(registry sql.SpatialRefSystemRegistry) *SRID {
+	ns := *s
+	ns.registry = registry
+	return &ns
 }
 
 // FunctionName implements sql.FunctionExpression
@@ -70,7 +86,11 @@ func (s *SRID) String() string {
 
 // WithChildren implements the Expression interface.
 func (s *SRID) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	return NewSRID(children...)
+	nse, err := NewSRID(children...)
+	if err != nil {
+		return nil, err
+	}
+	return nse.(*SRID).WithRegistry(s.registry), nil
 }
 
 // Eval implements the sql.Expression interface.
@@ -86,19 +106,13 @@ func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	// If just one argument, return X
+	// If just one argument, return the SRID of the geometry value
 	if len(s.ChildExpressions) == 1 {
-		// Check that it is a geometry type
-		switch g := g.(type) {
-		case sql.Point:
-			return g.SRID, nil
-		case sql.Linestring:
-			return g.SRID, nil
-		case sql.Polygon:
-			return g.SRID, nil
-		default:
+		gv, ok := g.(sql.GeometryValue)
+		if !ok {
 			return nil, sql.ErrIllegalGISValue.New(g)
 		}
+		return gv.GetSRID(), nil
 	}
 
 	// Evaluate second argument
@@ -121,20 +135,19 @@ func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// Type assertion
 	_srid := uint32(srid.(int32))
 
-	// Must be either 0 or 4230
-	if _srid != 0 && _srid != 4230 {
+	// Validate the SRID against the registry instead of a hard-coded whitelist
+	registry := s.registry
+	if registry == nil {
+		registry = sql.NewDefaultSpatialRefSystemRegistry()
+	}
+	if _, err := registry.Lookup(_srid); err != nil {
 		return nil, ErrInvalidSRID.New(_srid)
 	}
 
-	// Create new geometry object with matching SRID
-	switch g := g.(type) {
-	case sql.Point:
-		return sql.Point{SRID: _srid, X: g.X, Y: g.Y}, nil
-	case sql.Linestring:
-		return sql.Linestring{SRID: _srid, Points: g.Points}, nil
-	case sql.Polygon:
-		return sql.Polygon{SRID: _srid, Lines: g.Lines}, nil
-	default:
+	// Create a new geometry value with matching SRID
+	gv, ok := g.(sql.GeometryValue)
+	if !ok {
 		return nil, sql.ErrIllegalGISValue.New(g)
 	}
+	return gv.SetSRID(_srid), nil
 }
\ No newline at end of file
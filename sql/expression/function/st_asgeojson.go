@@ -0,0 +1,84 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AsGeoJSON is a function that returns the GeoJSON representation of a geometry value.
+type AsGeoJSON struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AsGeoJSON)(nil)
+
+// NewAsGeoJSON creates a new ST_AsGeoJSON expression.
+func NewAsGeoJSON(arg sql.Expression) sql.Expression {
+	return &AsGeoJSON{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsGeoJSON) FunctionName() string {
+	return "st_asgeojson"
+}
+
+// Description implements sql.FunctionExpression
+func (a *AsGeoJSON) Description() string {
+	return "returns the GeoJSON representation of the given geometry value."
+}
+
+// Type implements the sql.Expression interface.
+func (a *AsGeoJSON) Type() sql.Type {
+	return sql.JSON
+}
+
+func (a *AsGeoJSON) String() string {
+	return fmt.Sprintf("ST_AsGeoJSON(%s)", a.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (a *AsGeoJSON) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAsGeoJSON(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (a *AsGeoJSON) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, nil
+	}
+
+	gv, ok := g.(sql.GeometryValue)
+	if !ok {
+		return nil, sql.ErrIllegalGISValue.New(g)
+	}
+
+	doc, err := sql.GeoJSONEncode(gv)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(doc), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AsText is a function that returns the Well-Known Text representation of a geometry value.
+type AsText struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AsText)(nil)
+
+// NewAsText creates a new ST_AsText expression.
+func NewAsText(arg sql.Expression) sql.Expression {
+	return &AsText{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsText) FunctionName() string {
+	return "st_astext"
+}
+
+// Description implements sql.FunctionExpression
+func (a *AsText) Description() string {
+	return "returns the Well-Known Text representation of the given geometry value."
+}
+
+// Type implements the sql.Expression interface.
+func (a *AsText) Type() sql.Type {
+	return sql.LongText
+}
+
+func (a *AsText) String() string {
+	return fmt.Sprintf("ST_AsText(%s)", a.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (a *AsText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAsText(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (a *AsText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, nil
+	}
+
+	gv, ok := g.(sql.GeometryValue)
+	if !ok {
+		return nil, sql.ErrIllegalGISValue.New(g)
+	}
+
+	return sql.WKTEncode(gv), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AsWKB is a function that returns the Well-Known Binary representation of a geometry value.
+type AsWKB struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AsWKB)(nil)
+
+// NewAsWKB creates a new ST_AsWKB expression.
+func NewAsWKB(arg sql.Expression) sql.Expression {
+	return &AsWKB{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsWKB) FunctionName() string {
+	return "st_aswkb"
+}
+
+// Description implements sql.FunctionExpression
+func (a *AsWKB) Description() string {
+	return "returns the Well-Known Binary representation of the given geometry value."
+}
+
+// Type implements the sql.Expression interface.
+func (a *AsWKB) Type() sql.Type {
+	return sql.LongBlob
+}
+
+func (a *AsWKB) String() string {
+	return fmt.Sprintf("ST_AsWKB(%s)", a.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (a *AsWKB) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAsWKB(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (a *AsWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, nil
+	}
+
+	gv, ok := g.(sql.GeometryValue)
+	if !ok {
+		return nil, sql.ErrIllegalGISValue.New(g)
+	}
+
+	return sql.WKBEncode(gv), nil
+}
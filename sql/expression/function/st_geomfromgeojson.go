@@ -0,0 +1,132 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// GeomFromGeoJSON is a function that returns a geometry value parsed from a GeoJSON document.
+type GeomFromGeoJSON struct {
+	expression.NaryExpression
+	registry sql.SpatialRefSystemRegistry
+}
+
+var _ sql.FunctionExpression = (*GeomFromGeoJSON)(nil)
+
+// NewGeomFromGeoJSON creates a new ST_GeomFromGeoJSON expression. Like
+// NewSRID, it validates the (optional, default 4326) SRID argument against
+// the server's default spatial reference system registry; callers that
+// need a different registry should swap it in with WithRegistry.
+func NewGeomFromGeoJSON(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_GeomFromGeoJSON", "1, 2 or 3", len(args))
+	}
+	return &GeomFromGeoJSON{
+		NaryExpression: expression.NaryExpression{ChildExpressions: args},
+		registry:       sql.NewDefaultSpatialRefSystemRegistry(),
+	}, nil
+}
+
+// WithRegistry returns a copy of g that validates its SRID argument against
+// registry instead of the default spatial reference system registry.
+func (g *GeomFromGeoJSON) WithRegistry(registry sql.SpatialRefSystemRegistry) *GeomFromGeoJSON {
+	ng := *g
+	ng.registry = registry
+	return &ng
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeomFromGeoJSON) FunctionName() string {
+	return "st_geomfromgeojson"
+}
+
+// Description implements sql.FunctionExpression
+func (g *GeomFromGeoJSON) Description() string {
+	return "parses a GeoJSON document and returns the resulting geometry value. The optional third argument tags the result with the given SRID (default 4326, matching GeoJSON's implicit CRS)."
+}
+
+// Type implements the sql.Expression interface.
+func (g *GeomFromGeoJSON) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (g *GeomFromGeoJSON) String() string {
+	var args = make([]string, len(g.ChildExpressions))
+	for i, arg := range g.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_GeomFromGeoJSON(%s)", strings.Join(args, ","))
+}
+
+// WithChildren implements the Expression interface.
+func (g *GeomFromGeoJSON) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	nge, err := NewGeomFromGeoJSON(children...)
+	if err != nil {
+		return nil, err
+	}
+	return nge.(*GeomFromGeoJSON).WithRegistry(g.registry), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (g *GeomFromGeoJSON) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := g.ChildExpressions[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	s, err := sql.LongText.Convert(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	geom, err := sql.GeoJSONDecode([]byte(s.(string)))
+	if err != nil {
+		return nil, err
+	}
+
+	// GeoJSON geometries are implicitly WGS 84 (SRID 4326) unless the third
+	// argument overrides it.
+	srid := uint32(4326)
+	if len(g.ChildExpressions) == 3 {
+		sridVal, err := g.ChildExpressions[2].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if sridVal == nil {
+			return nil, nil
+		}
+		i, err := sql.Int32.Convert(sridVal)
+		if err != nil {
+			return nil, err
+		}
+		srid = uint32(i.(int32))
+	}
+
+	srs, err := g.registry.Lookup(srid)
+	if err != nil {
+		return nil, ErrInvalidSRID.New(srid)
+	}
+	geom = geom.SetSRID(srid)
+	geom = sql.NormalizeAxisOrder(geom, srs)
+	return geom, nil
+}
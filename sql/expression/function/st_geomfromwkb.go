@@ -0,0 +1,129 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// GeomFromWKB is a function that returns a geometry value parsed from Well-Known Binary.
+type GeomFromWKB struct {
+	expression.NaryExpression
+	registry sql.SpatialRefSystemRegistry
+}
+
+var _ sql.FunctionExpression = (*GeomFromWKB)(nil)
+
+// NewGeomFromWKB creates a new ST_GeomFromWKB expression. Like NewSRID, it
+// validates the optional SRID argument against the server's default spatial
+// reference system registry; callers that need a different registry should
+// swap it in with WithRegistry.
+func NewGeomFromWKB(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_GeomFromWKB", "1 or 2", len(args))
+	}
+	return &GeomFromWKB{
+		NaryExpression: expression.NaryExpression{ChildExpressions: args},
+		registry:       sql.NewDefaultSpatialRefSystemRegistry(),
+	}, nil
+}
+
+// WithRegistry returns a copy of g that validates its SRID argument against
+// registry instead of the default spatial reference system registry.
+func (g *GeomFromWKB) WithRegistry(registry sql.SpatialRefSystemRegistry) *GeomFromWKB {
+	ng := *g
+	ng.registry = registry
+	return &ng
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeomFromWKB) FunctionName() string {
+	return "st_geomfromwkb"
+}
+
+// Description implements sql.FunctionExpression
+func (g *GeomFromWKB) Description() string {
+	return "parses a WKB byte string, optionally tagging the result with the given SRID, and returns the resulting geometry value."
+}
+
+// Type implements the sql.Expression interface.
+func (g *GeomFromWKB) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (g *GeomFromWKB) String() string {
+	var args = make([]string, len(g.ChildExpressions))
+	for i, arg := range g.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_GeomFromWKB(%s)", strings.Join(args, ","))
+}
+
+// WithChildren implements the Expression interface.
+func (g *GeomFromWKB) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	nge, err := NewGeomFromWKB(children...)
+	if err != nil {
+		return nil, err
+	}
+	return nge.(*GeomFromWKB).WithRegistry(g.registry), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (g *GeomFromWKB) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	wkb, err := g.ChildExpressions[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if wkb == nil {
+		return nil, nil
+	}
+
+	b, err := sql.LongBlob.Convert(wkb)
+	if err != nil {
+		return nil, err
+	}
+
+	geom, err := sql.WKBDecode(b.([]byte))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(g.ChildExpressions) == 2 {
+		srid, err := g.ChildExpressions[1].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if srid == nil {
+			return nil, nil
+		}
+		i, err := sql.Int32.Convert(srid)
+		if err != nil {
+			return nil, err
+		}
+		_srid := uint32(i.(int32))
+		srs, err := g.registry.Lookup(_srid)
+		if err != nil {
+			return nil, ErrInvalidSRID.New(_srid)
+		}
+		geom = geom.SetSRID(_srid)
+		geom = sql.NormalizeAxisOrder(geom, srs)
+	}
+
+	return geom, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidGeoJSON is returned when a document passed to ST_GeomFromGeoJSON
+// is not a well-formed GeoJSON geometry object.
+var ErrInvalidGeoJSON = errors.NewKind("invalid GeoJSON geometry: %s")
+
+type geoJSONDoc struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []geoJSONDoc    `json:"geometries,omitempty"`
+}
+
+// GeoJSONEncode serializes g as a GeoJSON geometry object, the format
+// produced by MySQL's ST_AsGeoJSON. SRID is not represented; callers that
+// need CRS information should consult GetSRID separately, mirroring
+// ST_AsGeoJSON's own behavior of only including a "crs" member for
+// non-default SRIDs via a separate argument MySQL handles at the SQL layer.
+func GeoJSONEncode(g GeometryValue) ([]byte, error) {
+	switch g := g.(type) {
+	case Point:
+		return json.Marshal(struct {
+			Type        string    `json:"type"`
+			Coordinates []float64 `json:"coordinates"`
+		}{"Point", []float64{g.X, g.Y}})
+	case Linestring:
+		return json.Marshal(struct {
+			Type        string        `json:"type"`
+			Coordinates [][]float64   `json:"coordinates"`
+		}{"LineString", pointsToCoords(g.Points)})
+	case Polygon:
+		return json.Marshal(struct {
+			Type        string          `json:"type"`
+			Coordinates [][][]float64   `json:"coordinates"`
+		}{"Polygon", linesToCoords(g.Lines)})
+	case MultiPoint:
+		return json.Marshal(struct {
+			Type        string      `json:"type"`
+			Coordinates [][]float64 `json:"coordinates"`
+		}{"MultiPoint", pointsToCoords(g.Points)})
+	case MultiLinestring:
+		return json.Marshal(struct {
+			Type        string        `json:"type"`
+			Coordinates [][][]float64 `json:"coordinates"`
+		}{"MultiLineString", linesToCoords(g.Lines)})
+	case MultiPolygon:
+		coords := make([][][][]float64, len(g.Polygons))
+		for i, p := range g.Polygons {
+			coords[i] = linesToCoords(p.Lines)
+		}
+		return json.Marshal(struct {
+			Type        string          `json:"type"`
+			Coordinates [][][][]float64 `json:"coordinates"`
+		}{"MultiPolygon", coords})
+	case GeometryCollection:
+		geometries := make([]json.RawMessage, len(g.Geoms))
+		for i, inner := range g.Geoms {
+			raw, err := GeoJSONEncode(inner)
+			if err != nil {
+				return nil, err
+			}
+			geometries[i] = raw
+		}
+		return json.Marshal(struct {
+			Type       string            `json:"type"`
+			Geometries []json.RawMessage `json:"geometries"`
+		}{"GeometryCollection", geometries})
+	case Geometry:
+		return GeoJSONEncode(g.Inner)
+	default:
+		return nil, ErrInvalidGeoJSON.New("unsupported geometry value")
+	}
+}
+
+func pointsToCoords(points []Point) [][]float64 {
+	coords := make([][]float64, len(points))
+	for i, p := range points {
+		coords[i] = []float64{p.X, p.Y}
+	}
+	return coords
+}
+
+func linesToCoords(lines []Linestring) [][][]float64 {
+	coords := make([][][]float64, len(lines))
+	for i, l := range lines {
+		coords[i] = pointsToCoords(l.Points)
+	}
+	return coords
+}
+
+// GeoJSONDecode parses a GeoJSON geometry object back into a GeometryValue.
+func GeoJSONDecode(data []byte) (GeometryValue, error) {
+	var doc geoJSONDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrInvalidGeoJSON.New(err.Error())
+	}
+	return decodeGeoJSONDoc(doc)
+}
+
+func decodeGeoJSONDoc(doc geoJSONDoc) (GeometryValue, error) {
+	switch doc.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil || len(c) != 2 {
+			return nil, ErrInvalidGeoJSON.New("bad Point coordinates")
+		}
+		return Point{X: c[0], Y: c[1]}, nil
+	case "LineString":
+		var c [][]float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil {
+			return nil, ErrInvalidGeoJSON.New("bad LineString coordinates")
+		}
+		return Linestring{Points: coordsToPoints(c)}, nil
+	case "Polygon":
+		var c [][][]float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil {
+			return nil, ErrInvalidGeoJSON.New("bad Polygon coordinates")
+		}
+		return Polygon{Lines: coordsToLines(c)}, nil
+	case "MultiPoint":
+		var c [][]float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil {
+			return nil, ErrInvalidGeoJSON.New("bad MultiPoint coordinates")
+		}
+		return MultiPoint{Points: coordsToPoints(c)}, nil
+	case "MultiLineString":
+		var c [][][]float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil {
+			return nil, ErrInvalidGeoJSON.New("bad MultiLineString coordinates")
+		}
+		return MultiLinestring{Lines: coordsToLines(c)}, nil
+	case "MultiPolygon":
+		var c [][][][]float64
+		if err := json.Unmarshal(doc.Coordinates, &c); err != nil {
+			return nil, ErrInvalidGeoJSON.New("bad MultiPolygon coordinates")
+		}
+		polys := make([]Polygon, len(c))
+		for i, p := range c {
+			polys[i] = Polygon{Lines: coordsToLines(p)}
+		}
+		return MultiPolygon{Polygons: polys}, nil
+	case "GeometryCollection":
+		geoms := make([]GeometryValue, len(doc.Geometries))
+		for i, inner := range doc.Geometries {
+			g, err := decodeGeoJSONDoc(inner)
+			if err != nil {
+				return nil, err
+			}
+			geoms[i] = g
+		}
+		return GeometryCollection{Geoms: geoms}, nil
+	default:
+		return nil, ErrInvalidGeoJSON.New("unknown type " + doc.Type)
+	}
+}
+
+func coordsToPoints(c [][]float64) []Point {
+	points := make([]Point, len(c))
+	for i, p := range c {
+		if len(p) >= 2 {
+			points[i] = Point{X: p[0], Y: p[1]}
+		}
+	}
+	return points
+}
+
+func coordsToLines(c [][][]float64) []Linestring {
+	lines := make([]Linestring, len(c))
+	for i, l := range c {
+		lines[i] = Linestring{Points: coordsToPoints(l)}
+	}
+	return lines
+}
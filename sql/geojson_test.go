@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		geom GeometryValue
+	}{
+		{"point", Point{X: 1, Y: 2}},
+		{"linestring", Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"polygon", Polygon{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}},
+		}}},
+		{"multipoint", MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+		{"multilinestring", MultiLinestring{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []Point{{X: 2, Y: 2}, {X: 3, Y: 3}}},
+		}}},
+		{"multipolygon", MultiPolygon{Polygons: []Polygon{
+			{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+		}}},
+		{"geometrycollection", GeometryCollection{Geoms: []GeometryValue{
+			Point{X: 1, Y: 1},
+			Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			data, err := GeoJSONEncode(test.geom)
+			require.NoError(err)
+			got, err := GeoJSONDecode(data)
+			require.NoError(err)
+			require.Equal(test.geom, got)
+		})
+	}
+}
+
+func TestGeoJSONDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "not json at all"},
+		{"unknown type", `{"type":"Circle","coordinates":[0,0]}`},
+		{"bad point coordinates", `{"type":"Point","coordinates":[1]}`},
+		{"bad point coordinates type", `{"type":"Point","coordinates":"nope"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			_, err := GeoJSONDecode([]byte(test.data))
+			require.Error(err)
+			require.True(ErrInvalidGeoJSON.Is(err))
+		})
+	}
+}
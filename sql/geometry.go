@@ -0,0 +1,53 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// GeometryValue is implemented by every concrete OGC geometry value
+// (Point, Linestring, Polygon, MultiPoint, MultiLinestring, MultiPolygon,
+// and GeometryCollection), letting geometry functions such as ST_SRID and
+// ST_AsText operate over the full type hierarchy without a type switch per
+// caller.
+type GeometryValue interface {
+	// GetSRID returns the spatial reference system identifier of this value.
+	GetSRID() uint32
+	// SetSRID returns a copy of this value with its SRID replaced by srid.
+	SetSRID(srid uint32) GeometryValue
+}
+
+// Geometry is the catch-all column value for the MySQL GEOMETRY type: it
+// wraps whichever concrete GeometryValue a column or expression actually
+// holds, the way MySQL itself allows any geometry subtype to be stored in a
+// GEOMETRY-typed column.
+type Geometry struct {
+	Inner GeometryValue
+}
+
+var _ GeometryValue = Geometry{}
+
+// GetSRID implements GeometryValue.
+func (g Geometry) GetSRID() uint32 {
+	if g.Inner == nil {
+		return 0
+	}
+	return g.Inner.GetSRID()
+}
+
+// SetSRID implements GeometryValue.
+func (g Geometry) SetSRID(srid uint32) GeometryValue {
+	if g.Inner == nil {
+		return g
+	}
+	return Geometry{Inner: g.Inner.SetSRID(srid)}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// This file implements GeometryValue for the existing Point, Linestring and
+// Polygon types, so that all three line up with the Multi* and
+// GeometryCollection types added alongside them.
+
+var (
+	_ GeometryValue = Point{}
+	_ GeometryValue = Linestring{}
+	_ GeometryValue = Polygon{}
+)
+
+// GetSRID implements GeometryValue.
+func (p Point) GetSRID() uint32 { return p.SRID }
+
+// SetSRID implements GeometryValue.
+func (p Point) SetSRID(srid uint32) GeometryValue {
+	p.SRID = srid
+	return p
+}
+
+// GetSRID implements GeometryValue.
+func (l Linestring) GetSRID() uint32 { return l.SRID }
+
+// SetSRID implements GeometryValue.
+func (l Linestring) SetSRID(srid uint32) GeometryValue {
+	l.SRID = srid
+	return l
+}
+
+// GetSRID implements GeometryValue.
+func (p Polygon) GetSRID() uint32 { return p.SRID }
+
+// SetSRID implements GeometryValue.
+func (p Polygon) SetSRID(srid uint32) GeometryValue {
+	p.SRID = srid
+	return p
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+
+	"gopkg.in/src-d/go-errors.v1"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/proto/query"
+)
+
+// ErrIllegalGISValue is returned when a value that isn't a GeometryValue (or
+// one of the raw encodings GeometryType.Convert accepts) is used where a
+// GEOMETRY column value is expected.
+var ErrIllegalGISValue = errors.NewKind("value of type %T is not a valid GIS value")
+
+// GeometryType is the column sql.Type for the MySQL GEOMETRY type. Unlike
+// the narrower Point/Linestring/Polygon column types, it accepts any
+// GeometryValue — a column declared GEOMETRY can hold any of the OGC
+// subtypes, with the concrete type only distinguished at the value level.
+type GeometryType struct{}
+
+var _ Type = GeometryType{}
+
+func (t GeometryType) String() string { return "geometry" }
+
+// Type implements Type.
+func (t GeometryType) Type() query.Type { return query.Type_GEOMETRY }
+
+// Zero implements Type.
+func (t GeometryType) Zero() interface{} { return nil }
+
+// Promote implements Type.
+func (t GeometryType) Promote() Type { return t }
+
+// Convert implements Type. It accepts an existing GeometryValue as-is, and
+// parses a []byte or string as WKB, matching how MySQL clients send
+// GEOMETRY column values over the wire.
+func (t GeometryType) Convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch v := v.(type) {
+	case GeometryValue:
+		return v, nil
+	case []byte:
+		return WKBDecode(v)
+	case string:
+		return WKBDecode([]byte(v))
+	default:
+		return nil, ErrIllegalGISValue.New(v)
+	}
+}
+
+// Compare implements Type by comparing the WKB encoding of the two values,
+// which at least gives a total, deterministic order; it does not imply any
+// spatial relationship between the two geometries.
+func (t GeometryType) Compare(a, b interface{}) (int, error) {
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	ag, ok := a.(GeometryValue)
+	if !ok {
+		return 0, ErrIllegalGISValue.New(a)
+	}
+	bg, ok := b.(GeometryValue)
+	if !ok {
+		return 0, ErrIllegalGISValue.New(b)
+	}
+
+	return bytes.Compare(WKBEncode(ag), WKBEncode(bg)), nil
+}
+
+// SQL implements Type.
+func (t GeometryType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+	gv, ok := v.(GeometryValue)
+	if !ok {
+		return sqltypes.Value{}, ErrIllegalGISValue.New(v)
+	}
+	return sqltypes.MakeTrusted(query.Type_GEOMETRY, WKBEncode(gv)), nil
+}
+
+// compareNulls reports whether either a or b is nil, and if so the Compare
+// result that follows the usual SQL "NULL sorts before everything else,
+// two NULLs are equal" convention.
+func compareNulls(a, b interface{}) (bool, int) {
+	if a == nil && b == nil {
+		return true, 0
+	}
+	if a == nil {
+		return true, -1
+	}
+	if b == nil {
+		return true, 1
+	}
+	return false, 0
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// GeometryCollection is the value type backing the MySQL GEOMETRYCOLLECTION
+// type: a heterogeneous collection of GeometryValues sharing a single SRID.
+// Unlike the other Multi* types it is not restricted to one element kind, so
+// it can hold any mix of Points, Linestrings, Polygons, or nested
+// collections.
+type GeometryCollection struct {
+	SRID  uint32
+	Geoms []GeometryValue
+}
+
+var _ GeometryValue = GeometryCollection{}
+
+// GetSRID implements GeometryValue.
+func (g GeometryCollection) GetSRID() uint32 { return g.SRID }
+
+// SetSRID implements GeometryValue.
+func (g GeometryCollection) SetSRID(srid uint32) GeometryValue {
+	g.SRID = srid
+	return g
+}
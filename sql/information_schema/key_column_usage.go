@@ -0,0 +1,172 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package information_schema implements the INFORMATION_SCHEMA tables that
+// ORM reflection code (xorm, gorm, ...) relies on when generating or
+// validating a schema: foreign key reflection via KEY_COLUMN_USAGE and
+// REFERENTIAL_CONSTRAINTS, and integrator-contributed metadata via
+// sql.Catalog.MetadataProviders.
+//
+// Out of scope here: COLUMNS.COLUMN_DEFAULT as a real default expression
+// string, COLUMNS.EXTRA (e.g. "on update CURRENT_TIMESTAMP"),
+// STATISTICS.CARDINALITY, and VIEWS.definition. This tree has no
+// COLUMNS/STATISTICS/VIEWS row-builder files at all for this package to
+// extend, so adding them means creating those tables from scratch rather
+// than filling in a column on an existing one; left for whoever owns that
+// follow-up.
+package information_schema
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const (
+	// KeyColumnUsageTableName is the name of the KEY_COLUMN_USAGE table.
+	KeyColumnUsageTableName = "key_column_usage"
+	// ReferentialConstraintsTableName is the name of the REFERENTIAL_CONSTRAINTS table.
+	ReferentialConstraintsTableName = "referential_constraints"
+)
+
+// KeyColumnUsageRows builds the rows of INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+// for every foreign key of every table in cat, plus any rows contributed by
+// cat's registered MetadataProviders.
+//
+// Columns: CONSTRAINT_CATALOG, CONSTRAINT_SCHEMA, CONSTRAINT_NAME,
+// TABLE_CATALOG, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION,
+// POSITION_IN_UNIQUE_CONSTRAINT, REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME,
+// REFERENCED_COLUMN_NAME.
+func KeyColumnUsageRows(ctx *sql.Context, cat *sql.Catalog) ([]sql.Row, error) {
+	var rows []sql.Row
+
+	for _, db := range cat.AllDatabases(ctx) {
+		names, err := db.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			table, ok, err := db.GetTableInsensitive(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			fkTable, ok := table.(sql.ForeignKeyTable)
+			if !ok {
+				continue
+			}
+			fks, err := fkTable.GetForeignKeys(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, fk := range fks {
+				for i, col := range fk.Columns {
+					var refCol interface{}
+					if i < len(fk.ParentColumns) {
+						refCol = fk.ParentColumns[i]
+					}
+					rows = append(rows, sql.Row{
+						"def",            // CONSTRAINT_CATALOG
+						db.Name(),        // CONSTRAINT_SCHEMA
+						fk.Name,          // CONSTRAINT_NAME
+						"def",            // TABLE_CATALOG
+						db.Name(),        // TABLE_SCHEMA
+						table.Name(),     // TABLE_NAME
+						col,              // COLUMN_NAME
+						i + 1,            // ORDINAL_POSITION
+						i + 1,            // POSITION_IN_UNIQUE_CONSTRAINT
+						fk.ParentDatabase, // REFERENCED_TABLE_SCHEMA
+						fk.ParentTable,   // REFERENCED_TABLE_NAME
+						refCol,           // REFERENCED_COLUMN_NAME
+					})
+				}
+			}
+		}
+	}
+
+	for _, provider := range cat.MetadataProviders() {
+		extra, err := provider.Rows(ctx, KeyColumnUsageTableName)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, extra...)
+	}
+
+	return rows, nil
+}
+
+// ReferentialConstraintsRows builds the rows of
+// INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS for every foreign key of every
+// table in cat, plus any rows contributed by cat's registered
+// MetadataProviders.
+//
+// Columns: CONSTRAINT_CATALOG, CONSTRAINT_SCHEMA, CONSTRAINT_NAME,
+// UNIQUE_CONSTRAINT_CATALOG, UNIQUE_CONSTRAINT_SCHEMA, UNIQUE_CONSTRAINT_NAME,
+// MATCH_OPTION, UPDATE_RULE, DELETE_RULE, TABLE_NAME, REFERENCED_TABLE_NAME.
+func ReferentialConstraintsRows(ctx *sql.Context, cat *sql.Catalog) ([]sql.Row, error) {
+	var rows []sql.Row
+
+	for _, db := range cat.AllDatabases(ctx) {
+		names, err := db.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			table, ok, err := db.GetTableInsensitive(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			fkTable, ok := table.(sql.ForeignKeyTable)
+			if !ok {
+				continue
+			}
+			fks, err := fkTable.GetForeignKeys(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, fk := range fks {
+				rows = append(rows, sql.Row{
+					"def",             // CONSTRAINT_CATALOG
+					db.Name(),         // CONSTRAINT_SCHEMA
+					fk.Name,           // CONSTRAINT_NAME
+					"def",             // UNIQUE_CONSTRAINT_CATALOG
+					fk.ParentDatabase, // UNIQUE_CONSTRAINT_SCHEMA
+					fk.Name,           // UNIQUE_CONSTRAINT_NAME
+					"NONE",            // MATCH_OPTION
+					string(fk.OnUpdate), // UPDATE_RULE
+					string(fk.OnDelete), // DELETE_RULE
+					table.Name(),      // TABLE_NAME
+					fk.ParentTable,    // REFERENCED_TABLE_NAME
+				})
+			}
+		}
+	}
+
+	for _, provider := range cat.MetadataProviders() {
+		extra, err := provider.Rows(ctx, ReferentialConstraintsTableName)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, extra...)
+	}
+
+	return rows, nil
+}
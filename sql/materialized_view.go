@@ -0,0 +1,140 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrAsOfMaterializedView is returned when a query applies an AS OF clause
+// to a materialized view. The analyzer's view resolver returns it for every
+// materialized view; it does not check whether the view's backing table is
+// versioned.
+var ErrAsOfMaterializedView = errors.NewKind("AS OF is not supported against materialized view %s.%s")
+
+// MaterializedViewInfo records everything the analyzer needs to decide
+// whether a materialized view is fresh, and where to scan it from instead
+// of re-expanding its definition.
+type MaterializedViewInfo struct {
+	// Database and Name identify the view itself.
+	Database string
+	Name     string
+	// BackingDatabase and BackingTable identify the table the view's output
+	// rows are actually stored in.
+	BackingDatabase string
+	BackingTable    string
+	// SourceVersions is the max-version/commit of each table the view
+	// definition reads from, as of the last refresh. A view is fresh as
+	// long as every entry here still matches the source table's current
+	// version.
+	SourceVersions map[TableID]string
+	// Incremental marks the view for incremental refresh. Nothing reads
+	// it: RefreshMaterializedView always does a full recompute.
+	Incremental bool
+}
+
+// MaterializedViewRegistry tracks the MaterializedViewInfo for every
+// materialized view a catalog knows about, so the analyzer's view resolver
+// can substitute a scan of the backing table for a fresh materialization
+// instead of expanding the view's definition.
+type MaterializedViewRegistry interface {
+	// Get returns the MaterializedViewInfo for the view (db, name), and
+	// whether it is in fact materialized.
+	Get(db, name string) (MaterializedViewInfo, bool)
+	// Put registers or replaces the MaterializedViewInfo for its own
+	// (Database, Name).
+	Put(info MaterializedViewInfo)
+	// Delete removes the MaterializedViewInfo for (db, name), e.g. when the
+	// materialized view is dropped.
+	Delete(db, name string)
+}
+
+type materializedViewRegistry struct {
+	mu    sync.RWMutex
+	views map[string]MaterializedViewInfo
+}
+
+var _ MaterializedViewRegistry = (*materializedViewRegistry)(nil)
+
+// NewMaterializedViewRegistry returns an empty, in-memory
+// MaterializedViewRegistry.
+func NewMaterializedViewRegistry() MaterializedViewRegistry {
+	return &materializedViewRegistry{views: make(map[string]MaterializedViewInfo)}
+}
+
+func materializedViewKey(db, name string) string { return db + "." + name }
+
+func (r *materializedViewRegistry) Get(db, name string) (MaterializedViewInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.views[materializedViewKey(db, name)]
+	return info, ok
+}
+
+func (r *materializedViewRegistry) Put(info MaterializedViewInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.views[materializedViewKey(info.Database, info.Name)] = info
+}
+
+func (r *materializedViewRegistry) Delete(db, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.views, materializedViewKey(db, name))
+}
+
+// materializedViewRegistries tracks each Catalog's MaterializedViewRegistry
+// by pointer, the same indirection sql.Catalog.MetadataProviders uses, so
+// that wiring a catalog for materialized views doesn't require a change to
+// Catalog's struct layout.
+var materializedViewRegistries = struct {
+	mu    sync.RWMutex
+	byCat map[*Catalog]MaterializedViewRegistry
+}{byCat: make(map[*Catalog]MaterializedViewRegistry)}
+
+// MaterializedViews returns c's MaterializedViewRegistry, lazily creating
+// an empty one the first time it's requested.
+func (c *Catalog) MaterializedViews() MaterializedViewRegistry {
+	materializedViewRegistries.mu.Lock()
+	defer materializedViewRegistries.mu.Unlock()
+	reg, ok := materializedViewRegistries.byCat[c]
+	if !ok {
+		reg = NewMaterializedViewRegistry()
+		materializedViewRegistries.byCat[c] = reg
+	}
+	return reg
+}
+
+// forgetMaterializedViews drops c's entry from materializedViewRegistries,
+// so c can be garbage collected once nothing else references it. Called
+// from Catalog.Dispose.
+func forgetMaterializedViews(c *Catalog) {
+	materializedViewRegistries.mu.Lock()
+	defer materializedViewRegistries.mu.Unlock()
+	delete(materializedViewRegistries.byCat, c)
+}
+
+// IsFresh reports whether info's recorded source versions still match
+// currentVersions for every table info depends on.
+func (info MaterializedViewInfo) IsFresh(currentVersions map[TableID]string) bool {
+	for table, version := range info.SourceVersions {
+		if currentVersions[table] != version {
+			return false
+		}
+	}
+	return true
+}
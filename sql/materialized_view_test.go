@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedViewRegistryPutGetDelete(t *testing.T) {
+	require := require.New(t)
+
+	reg := NewMaterializedViewRegistry()
+
+	_, ok := reg.Get("db", "v")
+	require.False(ok)
+
+	info := MaterializedViewInfo{Database: "db", Name: "v", BackingTable: "v_backing"}
+	reg.Put(info)
+
+	got, ok := reg.Get("db", "v")
+	require.True(ok)
+	require.Equal(info, got)
+
+	reg.Delete("db", "v")
+	_, ok = reg.Get("db", "v")
+	require.False(ok)
+}
+
+func TestCatalogMaterializedViewsLazyPerCatalog(t *testing.T) {
+	require := require.New(t)
+
+	a := new(Catalog)
+	b := new(Catalog)
+
+	a.MaterializedViews().Put(MaterializedViewInfo{Database: "db", Name: "v"})
+
+	_, ok := b.MaterializedViews().Get("db", "v")
+	require.False(ok, "a's registration must not be visible through b's pointer identity")
+
+	_, ok = a.MaterializedViews().Get("db", "v")
+	require.True(ok, "MaterializedViews must return the same registry on repeated calls")
+
+	a.Dispose()
+	_, ok = a.MaterializedViews().Get("db", "v")
+	require.False(ok, "Dispose should drop a's registry so a fresh empty one is created on next access")
+}
+
+func TestMaterializedViewInfoIsFresh(t *testing.T) {
+	require := require.New(t)
+
+	info := MaterializedViewInfo{
+		SourceVersions: map[TableID]string{
+			{Database: "db", Table: "t"}: "v1",
+		},
+	}
+
+	require.True(info.IsFresh(map[TableID]string{{Database: "db", Table: "t"}: "v1"}))
+	require.False(info.IsFresh(map[TableID]string{{Database: "db", Table: "t"}: "v2"}))
+	require.False(info.IsFresh(nil))
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MultiLinestring is the value type backing the MySQL MULTILINESTRING
+// geometry type: a collection of Linestrings sharing a single SRID.
+type MultiLinestring struct {
+	SRID  uint32
+	Lines []Linestring
+}
+
+var _ GeometryValue = MultiLinestring{}
+
+// GetSRID implements GeometryValue.
+func (l MultiLinestring) GetSRID() uint32 { return l.SRID }
+
+// SetSRID implements GeometryValue.
+func (l MultiLinestring) SetSRID(srid uint32) GeometryValue {
+	l.SRID = srid
+	return l
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MultiPoint is the value type backing the MySQL MULTIPOINT geometry type:
+// an unordered collection of Points sharing a single SRID.
+type MultiPoint struct {
+	SRID   uint32
+	Points []Point
+}
+
+var _ GeometryValue = MultiPoint{}
+
+// GetSRID implements GeometryValue.
+func (p MultiPoint) GetSRID() uint32 { return p.SRID }
+
+// SetSRID implements GeometryValue.
+func (p MultiPoint) SetSRID(srid uint32) GeometryValue {
+	p.SRID = srid
+	return p
+}
@@ -30,19 +30,82 @@ func NewCachedResults(n sql.Node) *CachedResults {
 	return &CachedResults{UnaryNode: UnaryNode{n}}
 }
 
+// NewSpillingCachedResults is like NewCachedResults, but falls back to
+// writing overflow rows through spillManager instead of giving up on
+// caching (the old noCache behavior) once the in-memory row cache reports
+// memory pressure.
+func NewSpillingCachedResults(n sql.Node, spillManager sql.SpillManager) *CachedResults {
+	return &CachedResults{UnaryNode: UnaryNode{n}, spillManager: spillManager}
+}
+
+// NewResultCachedResults is like NewCachedResults, but additionally admits
+// its result rows into resultCache (keyed by PlanHash(ctx, n)) so later,
+// unrelated executions of an equivalent plan can reuse them without
+// re-running Child at all, rather than only within a single execution's
+// lifetime. cost is the analyzer's estimated cost of n, used by
+// resultCache's admission policy, and tables is n's resolved read set, used
+// to invalidate the entry when one of those tables is written.
+//
+// No analyzer pass calls this constructor: wiring it in would mean an
+// analyzer rule that estimates cost, collects a plan's resolved table read
+// set, and wraps eligible top-level query plans with it — and this tree
+// has no sql/analyzer.Analyzer struct definition at all to add a
+// sql.ResultCache field to (every analyzer file here only ever receives
+// *Analyzer as a parameter). Until that struct exists to extend, this is a
+// fully-built, directly testable node with no path from a real query to it.
+func NewResultCachedResults(ctx *sql.Context, n sql.Node, resultCache sql.ResultCache, cost int64, tables []sql.TableID) *CachedResults {
+	return &CachedResults{
+		UnaryNode:   UnaryNode{n},
+		resultCache: resultCache,
+		planHash:    PlanHash(ctx, n),
+		cost:        cost,
+		tables:      tables,
+	}
+}
+
 type CachedResults struct {
 	UnaryNode
 	cache   sql.RowsCache
 	dispose sql.DisposeFunc
 	mutex   sync.Mutex
 	noCache bool
+
+	// resultCache, when non-nil, is consulted before Child.RowIter runs and
+	// admitted into after it completes, making this node's results visible
+	// to other executions, not just repeat reads of the same execution.
+	resultCache sql.ResultCache
+	planHash    string
+	cost        int64
+	tables      []sql.TableID
+
+	// spillManager, when non-nil, lets an in-progress cachedResultsIter
+	// spill overflow rows to disk instead of abandoning caching entirely
+	// (the noCache fallback) once ctx.Memory reports pressure.
+	spillManager sql.SpillManager
+	// spillWriter is set once this node's rows have been spilled to disk.
+	// Each RowIter call after that opens a fresh sql.SpillReader over it
+	// instead of recomputing Child or re-spilling.
+	spillWriter sql.SpillWriter
 }
 
 func (n *CachedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error) {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
+
+	if n.resultCache != nil {
+		if rows, ok := n.resultCache.Get(n.planHash); ok {
+			return sql.RowsToRowIter(rows...), nil
+		}
+	}
+
 	if n.cache != nil {
 		return sql.RowsToRowIter(n.cache.Get()...), nil
+	} else if n.spillWriter != nil {
+		reader, err := n.spillWriter.Reader()
+		if err != nil {
+			return nil, err
+		}
+		return &spillRowIter{reader}, nil
 	} else if n.noCache {
 		return n.UnaryNode.Child.RowIter(ctx, r)
 	}
@@ -51,13 +114,30 @@ func (n *CachedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error
 		return nil, err
 	}
 	cache, dispose := ctx.Memory.NewRowsCache()
-	return &cachedResultsIter{n, ci, cache, dispose}, nil
+	return &cachedResultsIter{n, ci, cache, dispose, nil}, nil
+}
+
+// spillRowIter adapts a sql.SpillReader to sql.RowIter for replaying a
+// CachedResults node's rows back out of its spill file.
+type spillRowIter struct {
+	reader sql.SpillReader
+}
+
+func (s *spillRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	return s.reader.Next()
+}
+
+func (s *spillRowIter) Close(ctx *sql.Context) error {
+	return s.reader.Close()
 }
 
 func (n *CachedResults) Dispose() {
 	if n.dispose != nil {
 		n.dispose()
 	}
+	if n.spillWriter != nil {
+		n.spillWriter.Remove()
+	}
 }
 
 func (n *CachedResults) String() string {
@@ -102,10 +182,32 @@ type cachedResultsIter struct {
 	iter    sql.RowIter
 	cache   sql.RowsCache
 	dispose sql.DisposeFunc
+
+	// spillWriter is non-nil once memory pressure has forced this iter to
+	// fall back to writing rows to disk instead of i.cache.
+	spillWriter sql.SpillWriter
 }
 
 func (i *cachedResultsIter) Next(ctx *sql.Context) (sql.Row, error) {
 	r, err := i.iter.Next(ctx)
+
+	if i.spillWriter != nil {
+		if err != nil {
+			if err == io.EOF {
+				i.finishSpilling()
+			} else {
+				i.spillWriter.Close()
+			}
+		} else if serr := i.spillWriter.Write(r); serr != nil {
+			i.spillWriter.Close()
+			i.spillWriter = nil
+			i.parent.mutex.Lock()
+			i.parent.noCache = true
+			i.parent.mutex.Unlock()
+		}
+		return r, err
+	}
+
 	if i.cache != nil {
 		if err != nil {
 			if err == io.EOF {
@@ -118,6 +220,12 @@ func (i *cachedResultsIter) Next(ctx *sql.Context) (sql.Row, error) {
 		} else {
 			aerr := i.cache.Add(r)
 			if aerr != nil {
+				if i.parent.spillManager != nil {
+					if serr := i.startSpilling(r); serr == nil {
+						i.cleanUp()
+						return r, err
+					}
+				}
 				i.cleanUp()
 				i.parent.mutex.Lock()
 				defer i.parent.mutex.Unlock()
@@ -128,10 +236,46 @@ func (i *cachedResultsIter) Next(ctx *sql.Context) (sql.Row, error) {
 	return r, err
 }
 
+// startSpilling begins writing to a new spill file, seeded with the rows
+// already accumulated in i.cache followed by pending, the row i.cache just
+// refused, so a memory cache overflow degrades to disk instead of
+// abandoning caching for the rest of this execution.
+func (i *cachedResultsIter) startSpilling(pending sql.Row) error {
+	w, err := i.parent.spillManager.NewWriter()
+	if err != nil {
+		return err
+	}
+	for _, row := range append(i.cache.Get(), pending) {
+		if err := w.Write(row); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	i.spillWriter = w
+	return nil
+}
+
+func (i *cachedResultsIter) finishSpilling() {
+	i.parent.mutex.Lock()
+	defer i.parent.mutex.Unlock()
+	if i.parent.spillWriter != nil || i.parent.cache != nil {
+		i.spillWriter.Close()
+		return
+	}
+	if err := i.spillWriter.Close(); err != nil {
+		i.parent.noCache = true
+		return
+	}
+	i.parent.spillWriter = i.spillWriter
+}
+
 func (i *cachedResultsIter) setCacheInParent() {
 	if i.parent.cache == nil {
 		i.parent.cache = i.cache
 		i.parent.dispose = i.dispose
+		if i.parent.resultCache != nil {
+			i.parent.resultCache.Put(i.parent.planHash, i.cache.Get(), i.parent.cost, i.parent.tables)
+		}
 		i.cache = nil
 		i.dispose = nil
 	} else {
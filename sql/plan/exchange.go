@@ -0,0 +1,165 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Exchange is the coordinator node the analyzer's distributeQuery pass
+// substitutes for a scan over a sharded table: each of its Shards children
+// produces rows from one shard, read concurrently, and RowIter merges them
+// into a single stream. It makes no ordering guarantees across shards;
+// operators above it (Sort, GroupBy, ...) that need a total order or a
+// merged aggregate still run against Exchange's output exactly as they
+// would against a single-shard scan.
+type Exchange struct {
+	// Shards holds one child sql.Node per shard, each scanning (and
+	// otherwise identical to) the equivalent single-shard plan.
+	Shards []sql.Node
+}
+
+var _ sql.Node = (*Exchange)(nil)
+
+// NewExchange returns an Exchange fanning out across shards.
+func NewExchange(shards []sql.Node) *Exchange {
+	return &Exchange{Shards: shards}
+}
+
+// Resolved implements sql.Node.
+func (e *Exchange) Resolved() bool {
+	for _, s := range e.Shards {
+		if !s.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements sql.Node.
+func (e *Exchange) Children() []sql.Node { return e.Shards }
+
+// WithChildren implements sql.Node.
+func (e *Exchange) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return &Exchange{Shards: children}, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (e *Exchange) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	for _, s := range e.Shards {
+		if !s.CheckPrivileges(ctx, opChecker) {
+			return false
+		}
+	}
+	return true
+}
+
+// Schema implements sql.Node. All shards scan the same logical table, so
+// they share a schema; Exchange just forwards the first shard's.
+func (e *Exchange) Schema() sql.Schema {
+	if len(e.Shards) == 0 {
+		return nil
+	}
+	return e.Shards[0].Schema()
+}
+
+func (e *Exchange) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("Exchange")
+	children := make([]string, len(e.Shards))
+	for i, s := range e.Shards {
+		children[i] = s.String()
+	}
+	_ = pr.WriteChildren(children...)
+	return pr.String()
+}
+
+// RowIter implements sql.Node. It starts every shard's RowIter
+// concurrently and merges their rows into a single channel as they arrive.
+func (e *Exchange) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if len(e.Shards) == 1 {
+		return e.Shards[0].RowIter(ctx, row)
+	}
+
+	rows := make(chan sql.Row)
+	errs := make(chan error, len(e.Shards))
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for _, shard := range e.Shards {
+		iter, err := shard.RowIter(ctx, row)
+		if err != nil {
+			close(done)
+			return nil, err
+		}
+		wg.Add(1)
+		go func(iter sql.RowIter) {
+			defer wg.Done()
+			defer iter.Close(ctx)
+			for {
+				r, err := iter.Next(ctx)
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+				select {
+				case rows <- r:
+				case <-done:
+					return
+				}
+			}
+		}(iter)
+	}
+
+	go func() {
+		wg.Wait()
+		close(rows)
+	}()
+
+	return &exchangeRowIter{rows: rows, errs: errs, done: done}, nil
+}
+
+type exchangeRowIter struct {
+	rows     chan sql.Row
+	errs     chan error
+	done     chan struct{}
+	closed   bool
+}
+
+func (e *exchangeRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	select {
+	case err := <-e.errs:
+		return nil, err
+	case r, ok := <-e.rows:
+		if !ok {
+			return nil, io.EOF
+		}
+		return r, nil
+	}
+}
+
+func (e *exchangeRowIter) Close(ctx *sql.Context) error {
+	if !e.closed {
+		e.closed = true
+		close(e.done)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// PlanHash returns a stable hash of n's shape, suitable for keying a
+// sql.ResultCache: it walks the (already analyzed) plan tree depth-first,
+// folding in each node's Go type, its String() rendering (which includes
+// its expressions), and the resolved identity of any table it reads.
+//
+// For a ResolvedTable whose underlying sql.Table implements
+// sql.VersionedTable, the table's CurrentVersion is folded in too, so a
+// write that advances a table's version changes every hash that reads it —
+// the cached entry from before the write becomes unreachable (it's simply
+// never looked up again) without needing an explicit invalidation call.
+// This is ResultCache's only defense against staleness for tables that
+// implement VersionedTable; for a ResolvedTable whose table does not, the
+// hash depends only on database/table name, and a result admitted before a
+// write can still be served after it until it expires via the cache's TTL
+// or is evicted by an explicit ResultCache.Invalidate call from a write
+// node. Today no write node in this tree calls Invalidate — callers reading
+// possibly-written, non-versioned tables through a ResultCache should keep
+// the TTL short enough to bound that window until such a node exists.
+func PlanHash(ctx *sql.Context, n sql.Node) string {
+	h := sha256.New()
+	hashNode(ctx, h, n)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashNode(ctx *sql.Context, h hashWriter, n sql.Node) {
+	if n == nil {
+		return
+	}
+	fmt.Fprintf(h, "(%T|%s", n, n.String())
+
+	if rt, ok := n.(*ResolvedTable); ok {
+		fmt.Fprintf(h, "|db=%s|table=%s", rt.Database.Name(), rt.Name())
+		if vt, ok := rt.Table.(sql.VersionedTable); ok {
+			if version, err := vt.CurrentVersion(ctx); err == nil {
+				fmt.Fprintf(h, "|version=%s", version)
+			}
+		}
+	}
+
+	for _, child := range n.Children() {
+		hashNode(ctx, h, child)
+	}
+	fmt.Fprint(h, ")")
+}
+
+// hashWriter is the subset of hash.Hash that hashNode needs; it's declared
+// separately so hashNode can be unit tested against a plain strings.Builder
+// without pulling in crypto/sha256.
+type hashWriter interface {
+	Write(p []byte) (int, error)
+}
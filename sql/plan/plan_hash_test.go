@@ -0,0 +1,36 @@
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestPlanHashStableForEquivalentPlans(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.Background(), sql.WithSession(sql.NewBaseSession()))
+
+	a := NewResetQueryCache(nil)
+	b := NewResetQueryCache(nil)
+
+	require.Equal(PlanHash(ctx, a), PlanHash(ctx, b))
+}
+
+func TestPlanHashDiffersAcrossNodeTypes(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.Background(), sql.WithSession(sql.NewBaseSession()))
+
+	require.NotEqual(PlanHash(ctx, NewResetQueryCache(nil)), PlanHash(ctx, NewShowShards(nil)))
+}
+
+func TestPlanHashNilNode(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.Background(), sql.WithSession(sql.NewBaseSession()))
+
+	require.NotPanics(func() {
+		PlanHash(ctx, nil)
+	})
+}
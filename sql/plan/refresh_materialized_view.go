@@ -0,0 +1,164 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"io"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrMaterializedViewBackingTableNotReplaceable is returned when a
+// materialized view's backing table doesn't implement sql.ReplaceableTable,
+// and so can't be reloaded by REFRESH MATERIALIZED VIEW.
+var ErrMaterializedViewBackingTableNotReplaceable = errors.NewKind("backing table %s.%s for materialized view %s.%s is not replaceable")
+
+// unversionedSourceMarker is the version RefreshMaterializedView records for
+// a source table that is missing or does not implement sql.VersionedTable.
+const unversionedSourceMarker = "\x00unversioned"
+
+// RefreshMaterializedView implements REFRESH MATERIALIZED VIEW <name>: it
+// re-runs the view's definition, truncates and reloads the backing table
+// with the result, and records the source tables' current versions so the
+// view is considered fresh until one of them changes again.
+//
+// Only a full recompute is implemented here. Incremental refresh (applying
+// +/- deltas from each source table's insert/delete log since the last
+// refresh, for the simple projection/filter/aggregation views the design
+// targets) needs those per-table delta logs, which nothing in this tree
+// produces yet; RefreshMaterializedView.Incremental is threaded through so
+// that support can be added without another signature change, but
+// RowIter always does a full recompute for now.
+type RefreshMaterializedView struct {
+	UnaryNode // Child is the view's definition, analyzed and resolved
+	Catalog     *sql.Catalog
+	Info        sql.MaterializedViewInfo
+	Incremental bool
+}
+
+var _ sql.Node = (*RefreshMaterializedView)(nil)
+
+// NewRefreshMaterializedView creates a new REFRESH MATERIALIZED VIEW node.
+// definition is the view's analyzed definition, and info describes the
+// materialization being refreshed.
+func NewRefreshMaterializedView(definition sql.Node, cat *sql.Catalog, info sql.MaterializedViewInfo) *RefreshMaterializedView {
+	return &RefreshMaterializedView{
+		UnaryNode: UnaryNode{definition},
+		Catalog:   cat,
+		Info:      info,
+	}
+}
+
+// Resolved implements sql.Node.
+func (r *RefreshMaterializedView) Resolved() bool {
+	return r.Child.Resolved()
+}
+
+// WithChildren implements sql.Node.
+func (r *RefreshMaterializedView) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	nr := *r
+	nr.Child = children[0]
+	return &nr, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (r *RefreshMaterializedView) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return r.Child.CheckPrivileges(ctx, opChecker)
+}
+
+// Schema implements sql.Node.
+func (r *RefreshMaterializedView) Schema() sql.Schema { return sql.OkResultSchema }
+
+func (r *RefreshMaterializedView) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("RefreshMaterializedView(%s.%s)", r.Info.Database, r.Info.Name)
+	_ = pr.WriteChildren(r.Child.String())
+	return pr.String()
+}
+
+// RowIter implements sql.Node. It recomputes the view's rows, replaces the
+// backing table's contents with them, and records each source table's
+// current version so the materialization is considered fresh again.
+func (r *RefreshMaterializedView) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	backingDb := r.Info.BackingDatabase
+	if backingDb == "" {
+		backingDb = r.Info.Database
+	}
+	table, err := r.Catalog.Table(ctx, backingDb, r.Info.BackingTable)
+	if err != nil {
+		return nil, err
+	}
+	replaceable, ok := table.(sql.ReplaceableTable)
+	if !ok {
+		return nil, ErrMaterializedViewBackingTableNotReplaceable.New(backingDb, r.Info.BackingTable, r.Info.Database, r.Info.Name)
+	}
+
+	if err := r.reload(ctx, replaceable); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[sql.TableID]string, len(r.Info.SourceVersions))
+	for id := range r.Info.SourceVersions {
+		// A source whose version can't be read is recorded under a marker
+		// that no current version equals, so IsFresh reports the view stale.
+		versions[id] = unversionedSourceMarker
+		srcTable, err := r.Catalog.Table(ctx, id.Database, id.Table)
+		if err != nil {
+			continue
+		}
+		if vt, ok := srcTable.(sql.VersionedTable); ok {
+			if version, err := vt.CurrentVersion(ctx); err == nil {
+				versions[id] = version
+			}
+		}
+	}
+	r.Info.SourceVersions = versions
+	r.Catalog.MaterializedViews().Put(r.Info)
+
+	return sql.RowsToRowIter(sql.NewRow(sql.OkResult{})), nil
+}
+
+func (r *RefreshMaterializedView) reload(ctx *sql.Context, table sql.ReplaceableTable) error {
+	replacer, err := table.Replacer(ctx)
+	if err != nil {
+		return err
+	}
+
+	iter, err := r.Child.RowIter(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := replacer.Insert(ctx, row); err != nil {
+			return err
+		}
+	}
+
+	return replacer.Close(ctx)
+}
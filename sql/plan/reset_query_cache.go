@@ -0,0 +1,67 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ResetQueryCache implements the RESET QUERY CACHE statement: it evicts
+// every entry from the server's sql.ResultCache.
+type ResetQueryCache struct {
+	resultCache sql.ResultCache
+}
+
+var _ sql.Node = (*ResetQueryCache)(nil)
+
+// NewResetQueryCache creates a new RESET QUERY CACHE statement against
+// resultCache.
+func NewResetQueryCache(resultCache sql.ResultCache) *ResetQueryCache {
+	return &ResetQueryCache{resultCache: resultCache}
+}
+
+// Resolved implements sql.Node.
+func (r *ResetQueryCache) Resolved() bool { return true }
+
+// Children implements sql.Node.
+func (r *ResetQueryCache) Children() []sql.Node { return nil }
+
+// WithChildren implements sql.Node.
+func (r *ResetQueryCache) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 0)
+	}
+	return r, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (r *ResetQueryCache) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation("", "", "", sql.PrivilegeType_Reload))
+}
+
+// Schema implements sql.Node.
+func (r *ResetQueryCache) Schema() sql.Schema { return sql.OkResultSchema }
+
+func (r *ResetQueryCache) String() string {
+	return "RESET QUERY CACHE"
+}
+
+// RowIter implements sql.Node.
+func (r *ResetQueryCache) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if r.resultCache != nil {
+		r.resultCache.Reset()
+	}
+	return sql.RowsToRowIter(sql.NewRow(sql.OkResult{})), nil
+}
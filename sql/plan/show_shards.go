@@ -0,0 +1,87 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/router"
+)
+
+// ShowShards implements the SHOW SHARDS statement: one row per (database,
+// table, shard) triple the server's router.Router knows about.
+type ShowShards struct {
+	Catalog *sql.Catalog
+}
+
+var _ sql.Node = (*ShowShards)(nil)
+
+// NewShowShards creates a new SHOW SHARDS statement.
+func NewShowShards(cat *sql.Catalog) *ShowShards {
+	return &ShowShards{Catalog: cat}
+}
+
+// Resolved implements sql.Node.
+func (s *ShowShards) Resolved() bool { return true }
+
+// Children implements sql.Node.
+func (s *ShowShards) Children() []sql.Node { return nil }
+
+// WithChildren implements sql.Node.
+func (s *ShowShards) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+	return s, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (s *ShowShards) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation("", "", "", sql.PrivilegeType_Select))
+}
+
+// Schema implements sql.Node.
+func (s *ShowShards) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Database", Type: sql.LongText},
+		{Name: "Table", Type: sql.LongText},
+		{Name: "Shard", Type: sql.LongText},
+		{Name: "Key_columns", Type: sql.LongText},
+	}
+}
+
+func (s *ShowShards) String() string { return "SHOW SHARDS" }
+
+// RowIter implements sql.Node.
+func (s *ShowShards) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	r, ok := router.ForCatalog(s.Catalog)
+	if !ok {
+		return sql.RowsToRowIter(), nil
+	}
+
+	var rows []sql.Row
+	for _, st := range r.AllTables() {
+		keyCols := ""
+		for i, c := range st.KeyColumns {
+			if i > 0 {
+				keyCols += ","
+			}
+			keyCols += c
+		}
+		for _, shard := range st.ShardNames() {
+			rows = append(rows, sql.Row{st.Database, st.Table, shard, keyCols})
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ResultCache is a cross-session cache of query result rows, keyed by a
+// canonicalized hash of the plan that produced them. Unlike
+// plan.CachedResults (which only avoids recomputing a single execution's
+// results while that execution's RowIter is still being read),
+// ResultCache's entries outlive the query that populated them and are
+// shared across sessions, so it needs its own admission and invalidation
+// policy.
+type ResultCache interface {
+	// Get returns the cached rows for planHash, and whether an unexpired
+	// entry was found.
+	Get(planHash string) ([]Row, bool)
+	// Put admits rows under planHash if cost meets the cache's admission
+	// threshold, associating them with the given tables so a later
+	// Invalidate call can evict them.
+	Put(planHash string, rows []Row, cost int64, tables []TableID)
+	// Invalidate evicts every cached entry whose read set intersects
+	// tables. Write nodes (Insert/Update/Delete/DDL) are meant to call this
+	// against the tables they modify before returning their own results;
+	// none of those nodes exist in this tree yet, so nothing calls
+	// Invalidate today. Until one does, plan.PlanHash folding in
+	// sql.VersionedTable.CurrentVersion is this cache's only protection
+	// against serving stale rows for a table that changed — entries for
+	// tables that aren't VersionedTable rely on TTL expiry alone.
+	Invalidate(tables ...TableID)
+	// Reset evicts every cached entry, implementing RESET QUERY CACHE.
+	Reset()
+	// Status reports cache occupancy and hit/miss counters for
+	// SHOW STATUS LIKE 'Qcache%'.
+	Status() QueryCacheStatus
+}
+
+// TableID identifies a table a cached plan read from (or a write node
+// writes to) for the purposes of cache invalidation. Database and Table are
+// compared case-sensitively, matching how the analyzer resolves table names
+// once a query has passed name resolution.
+type TableID struct {
+	Database string
+	Table    string
+}
+
+// QueryCacheStatus is a snapshot of ResultCache counters, surfaced to
+// clients via SHOW STATUS LIKE 'Qcache%', mirroring the Qcache_* status
+// variables MySQL itself exposes for its (now-removed) query cache.
+type QueryCacheStatus struct {
+	Hits        int64
+	Inserts     int64
+	NotCached   int64
+	Invalidations int64
+	EntriesCount  int64
+}
+
+// resultCacheEntry is one admitted cache entry.
+type resultCacheEntry struct {
+	planHash string
+	rows     []Row
+	tables   []TableID
+	expires  time.Time
+}
+
+// lruTTLResultCache is the default ResultCache: an LRU eviction order with
+// a cap on the number of entries, a TTL per entry, and admission gated on an estimated cost
+// threshold so cheap queries don't displace expensive ones.
+type lruTTLResultCache struct {
+	mu sync.Mutex
+
+	maxEntries   int
+	ttl          time.Duration
+	minCacheCost int64
+
+	order   *list.List // of *resultCacheEntry, front = most recently used
+	entries map[string]*list.Element
+
+	status QueryCacheStatus
+}
+
+var _ ResultCache = (*lruTTLResultCache)(nil)
+
+// NewResultCache returns a ResultCache that admits only plans whose
+// estimated cost is >= minCacheCost, holds at most maxEntries rows sets at
+// once (evicting least-recently-used first), and expires entries after ttl.
+func NewResultCache(maxEntries int, ttl time.Duration, minCacheCost int64) ResultCache {
+	return &lruTTLResultCache{
+		maxEntries:   maxEntries,
+		ttl:          ttl,
+		minCacheCost: minCacheCost,
+		order:        list.New(),
+		entries:      make(map[string]*list.Element),
+	}
+}
+
+func (c *lruTTLResultCache) Get(planHash string) ([]Row, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[planHash]
+	if !ok {
+		c.status.NotCached++
+		return nil, false
+	}
+
+	entry := el.Value.(*resultCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.removeElement(el)
+		c.status.NotCached++
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	c.status.Hits++
+	return entry.rows, true
+}
+
+func (c *lruTTLResultCache) Put(planHash string, rows []Row, cost int64, tables []TableID) {
+	if cost < c.minCacheCost {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[planHash]; ok {
+		c.removeElement(el)
+	}
+
+	entry := &resultCacheEntry{
+		planHash: planHash,
+		rows:     rows,
+		tables:   tables,
+		expires:  time.Now().Add(c.ttl),
+	}
+	el := c.order.PushFront(entry)
+	c.entries[planHash] = el
+	c.status.Inserts++
+	c.status.EntriesCount = int64(len(c.entries))
+
+	for c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *lruTTLResultCache) Invalidate(tables ...TableID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	invalidate := make(map[TableID]bool, len(tables))
+	for _, t := range tables {
+		invalidate[t] = true
+	}
+
+	var next *list.Element
+	for el := c.order.Front(); el != nil; el = next {
+		next = el.Next()
+		entry := el.Value.(*resultCacheEntry)
+		for _, t := range entry.tables {
+			if invalidate[t] {
+				c.removeElement(el)
+				c.status.Invalidations++
+				break
+			}
+		}
+	}
+}
+
+func (c *lruTTLResultCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.status.EntriesCount = 0
+}
+
+func (c *lruTTLResultCache) Status() QueryCacheStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// removeElement must be called with c.mu held.
+func (c *lruTTLResultCache) removeElement(el *list.Element) {
+	if el == nil {
+		return
+	}
+	entry := el.Value.(*resultCacheEntry)
+	delete(c.entries, entry.planHash)
+	c.order.Remove(el)
+	c.status.EntriesCount = int64(len(c.entries))
+}
@@ -0,0 +1,91 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCacheGetPutMiss(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(10, time.Minute, 0)
+
+	_, ok := c.Get("h1")
+	require.False(ok)
+
+	rows := []Row{{1}, {2}}
+	c.Put("h1", rows, 100, []TableID{{Database: "db", Table: "t"}})
+
+	got, ok := c.Get("h1")
+	require.True(ok)
+	require.Equal(rows, got)
+}
+
+func TestResultCacheAdmissionCost(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(10, time.Minute, 50)
+	c.Put("cheap", []Row{{1}}, 10, nil)
+
+	_, ok := c.Get("cheap")
+	require.False(ok, "plans cheaper than minCacheCost should not be admitted")
+}
+
+func TestResultCacheTTLExpiry(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(10, time.Millisecond, 0)
+	c.Put("h1", []Row{{1}}, 100, nil)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := c.Get("h1")
+	require.False(ok)
+}
+
+func TestResultCacheLRUEviction(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(1, time.Minute, 0)
+	c.Put("h1", []Row{{1}}, 100, nil)
+	c.Put("h2", []Row{{2}}, 100, nil)
+
+	_, ok := c.Get("h1")
+	require.False(ok, "h1 should have been evicted once the cache exceeded maxEntries")
+
+	_, ok = c.Get("h2")
+	require.True(ok)
+}
+
+func TestResultCacheInvalidate(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(10, time.Minute, 0)
+	tableA := TableID{Database: "db", Table: "a"}
+	tableB := TableID{Database: "db", Table: "b"}
+
+	c.Put("reads-a", []Row{{1}}, 100, []TableID{tableA})
+	c.Put("reads-b", []Row{{2}}, 100, []TableID{tableB})
+
+	c.Invalidate(tableA)
+
+	_, ok := c.Get("reads-a")
+	require.False(ok)
+
+	_, ok = c.Get("reads-b")
+	require.True(ok, "invalidating tableA must not evict an entry that only reads tableB")
+}
+
+func TestResultCacheReset(t *testing.T) {
+	require := require.New(t)
+
+	c := NewResultCache(10, time.Minute, 0)
+	c.Put("h1", []Row{{1}}, 100, nil)
+	c.Reset()
+
+	_, ok := c.Get("h1")
+	require.False(ok)
+	require.Equal(int64(0), c.Status().EntriesCount)
+}
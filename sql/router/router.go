@@ -0,0 +1,154 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package router lets a single go-mysql-server instance front multiple
+// backend sql.Database implementations sharded by a user-supplied key
+// function on one or more columns per table, the way a MySQL proxy
+// federates several real MySQL instances behind one endpoint.
+package router
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ShardKeyFunc computes the shard a row belongs to from its shard key
+// column values, in the order ShardedTable.KeyColumns lists them. It
+// returns the name of one of ShardedTable.Shards.
+type ShardKeyFunc func(keyValues []interface{}) (shard string, err error)
+
+// ShardedTable describes how a single table is distributed across shards:
+// which columns form its shard key, the function that maps key values to a
+// shard name, and the backing sql.Database for each shard.
+type ShardedTable struct {
+	Database    string
+	Table       string
+	KeyColumns  []string
+	KeyFunc     ShardKeyFunc
+	Shards      map[string]sql.Database // shard name -> backend database
+}
+
+// Router is the catalog of ShardedTables a server knows how to federate.
+// It's consulted by the analyzer's distributeQuery pass after resolveViews
+// to decide whether a table scan needs to fan out across shards.
+type Router struct {
+	mu     sync.RWMutex
+	tables map[string]ShardedTable // "db.table" -> ShardedTable
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{tables: make(map[string]ShardedTable)}
+}
+
+func routerKey(db, table string) string { return db + "." + table }
+
+// Register adds or replaces the ShardedTable for (st.Database, st.Table).
+func (r *Router) Register(st ShardedTable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tables[routerKey(st.Database, st.Table)] = st
+}
+
+// Lookup returns the ShardedTable registered for (db, table), and whether
+// that table is in fact sharded.
+func (r *Router) Lookup(db, table string) (ShardedTable, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	st, ok := r.tables[routerKey(db, table)]
+	return st, ok
+}
+
+// ShardNames returns st's shard names in a stable (sorted) order, so
+// plans built from them (e.g. Exchange's per-shard children) are
+// deterministic.
+func (st ShardedTable) ShardNames() []string {
+	names := make([]string, 0, len(st.Shards))
+	for name := range st.Shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AllTables returns every ShardedTable the Router knows about, in no
+// particular order. It's used by SHOW SHARDS.
+func (r *Router) AllTables() []ShardedTable {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tables := make([]ShardedTable, 0, len(r.tables))
+	for _, st := range r.tables {
+		tables = append(tables, st)
+	}
+	return tables
+}
+
+// BroadcastDDL runs exec against every shard of st and returns the first
+// error encountered, after giving every shard a chance to run regardless of
+// earlier failures, so a single unreachable shard doesn't leave the others
+// unmigrated. It's the hook CREATE/ALTER/DROP TABLE on a sharded table
+// should call instead of running DDL against a single backend.
+//
+// Nothing in this tree calls it yet: there are no CREATE/ALTER/DROP TABLE
+// plan nodes here at all for it to be wired into.
+func BroadcastDDL(st ShardedTable, exec func(db sql.Database) error) error {
+	var firstErr error
+	for _, name := range st.ShardNames() {
+		if err := exec(st.Shards[name]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// catalogRouters associates a Router with the sql.Catalog it federates,
+// keyed by pointer so that wiring a catalog for sharding doesn't require a
+// change to sql.Catalog's struct layout (the same indirection
+// sql.Catalog.MetadataProviders and sql.Catalog.MaterializedViews use,
+// mirrored here rather than in package sql since Router itself depends on
+// sql.Database).
+var catalogRouters = struct {
+	mu    sync.RWMutex
+	byCat map[*sql.Catalog]*Router
+}{byCat: make(map[*sql.Catalog]*Router)}
+
+// RegisterCatalog associates r with cat, so analyzer passes that only have
+// cat on hand (e.g. distributeQuery) can find it via ForCatalog.
+func RegisterCatalog(cat *sql.Catalog, r *Router) {
+	catalogRouters.mu.Lock()
+	defer catalogRouters.mu.Unlock()
+	catalogRouters.byCat[cat] = r
+}
+
+// ForCatalog returns the Router registered for cat, if any.
+func ForCatalog(cat *sql.Catalog) (*Router, bool) {
+	catalogRouters.mu.RLock()
+	defer catalogRouters.mu.RUnlock()
+	r, ok := catalogRouters.byCat[cat]
+	return r, ok
+}
+
+// UnregisterCatalog drops cat's Router association, so cat can be garbage
+// collected. Callers that called RegisterCatalog must call this (typically
+// alongside cat.Dispose) when they're done with cat — catalogRouters is
+// keyed by pointer the same way sql.Catalog's own MetadataProviders and
+// MaterializedViews registries are, and has the same leak if never cleaned
+// up.
+func UnregisterCatalog(cat *sql.Catalog) {
+	catalogRouters.mu.Lock()
+	defer catalogRouters.mu.Unlock()
+	delete(catalogRouters.byCat, cat)
+}
@@ -0,0 +1,70 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestRouterRegisterLookup(t *testing.T) {
+	require := require.New(t)
+
+	r := NewRouter()
+
+	_, ok := r.Lookup("db", "orders")
+	require.False(ok)
+
+	st := ShardedTable{
+		Database:   "db",
+		Table:      "orders",
+		KeyColumns: []string{"customer_id"},
+		Shards:     map[string]sql.Database{"shard0": nil, "shard1": nil},
+	}
+	r.Register(st)
+
+	got, ok := r.Lookup("db", "orders")
+	require.True(ok)
+	require.Equal(st.KeyColumns, got.KeyColumns)
+	require.Equal([]string{"shard0", "shard1"}, got.ShardNames())
+
+	require.Len(r.AllTables(), 1)
+}
+
+func TestBroadcastDDLRunsEveryShard(t *testing.T) {
+	require := require.New(t)
+
+	st := ShardedTable{
+		Database: "db",
+		Table:    "orders",
+		Shards:   map[string]sql.Database{"shard0": nil, "shard1": nil, "shard2": nil},
+	}
+
+	var ran []string
+	err := BroadcastDDL(st, func(db sql.Database) error {
+		ran = append(ran, "x")
+		return nil
+	})
+	require.NoError(err)
+	require.Len(ran, 3, "every shard should be attempted even though exec ignores which shard it was given")
+}
+
+func TestRegisterCatalogAndUnregister(t *testing.T) {
+	require := require.New(t)
+
+	cat := new(sql.Catalog)
+	r := NewRouter()
+
+	_, ok := ForCatalog(cat)
+	require.False(ok)
+
+	RegisterCatalog(cat, r)
+	got, ok := ForCatalog(cat)
+	require.True(ok)
+	require.Same(r, got)
+
+	UnregisterCatalog(cat)
+	_, ok = ForCatalog(cat)
+	require.False(ok, "UnregisterCatalog must drop the association so cat can be garbage collected")
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// AxisOrder describes the order in which a spatial reference system expects
+// coordinate axes to be supplied in WKT/WKB/GeoJSON source text.
+// ST_GeomFromText, ST_GeomFromWKB and ST_GeomFromGeoJSON consult it (via
+// NormalizeAxisOrder) to reorder an AxisOrderLatLong SRS's coordinates into
+// go-mysql-server's internal (longitude, latitude) storage order; ST_SRID
+// does not, since it only retags existing SRID metadata.
+type AxisOrder byte
+
+const (
+	// AxisOrderLongLat is the traditional GIS (x, y) / (longitude, latitude) order.
+	AxisOrderLongLat AxisOrder = iota
+	// AxisOrderLatLong is the EPSG-authoritative (latitude, longitude) order, used
+	// by geographic SRSs such as EPSG:4326.
+	AxisOrderLatLong
+)
+
+// SpatialRefSystem describes a single entry of an EPSG-style spatial
+// reference system table: the SRID it is keyed by, a human readable name,
+// and the axis order that coordinates for it must be given in.
+type SpatialRefSystem struct {
+	SRID      uint32
+	Name      string
+	AxisOrder AxisOrder
+}
+
+// SpatialRefSystemRegistry is the catalog of spatial reference systems a
+// server understands. It replaces the small hard-coded SRID whitelist that
+// used to live in the ST_SRID implementation, and is pluggable at server
+// construction so integrators can register custom or additional SRSes.
+type SpatialRefSystemRegistry interface {
+	// Lookup returns the SpatialRefSystem registered under srid, or
+	// ErrUnknownSpatialRefSystem if none is registered.
+	Lookup(srid uint32) (SpatialRefSystem, error)
+	// Register adds or replaces the SpatialRefSystem entry for srs.SRID.
+	Register(srs SpatialRefSystem)
+}
+
+var ErrUnknownSpatialRefSystem = errors.NewKind("There's no spatial reference with SRID %d")
+
+// defaultSpatialRefSystemRegistry is a simple mutex-guarded map
+// implementation of SpatialRefSystemRegistry, seeded with the spatial
+// reference systems go-mysql-server has historically accepted.
+type defaultSpatialRefSystemRegistry struct {
+	mu   sync.RWMutex
+	srss map[uint32]SpatialRefSystem
+}
+
+// NewDefaultSpatialRefSystemRegistry returns a SpatialRefSystemRegistry
+// pre-populated with SRID 0 (the "no SRS" default used for Cartesian
+// geometry), SRID 4230 (the ED50 system this package has supported since
+// before the registry existed), and SRID 4326 (WGS 84, the system used by
+// GPS and most web mapping).
+func NewDefaultSpatialRefSystemRegistry() SpatialRefSystemRegistry {
+	r := &defaultSpatialRefSystemRegistry{srss: make(map[uint32]SpatialRefSystem)}
+	r.Register(SpatialRefSystem{SRID: 0, Name: "", AxisOrder: AxisOrderLongLat})
+	r.Register(SpatialRefSystem{SRID: 4230, Name: "ED50", AxisOrder: AxisOrderLongLat})
+	r.Register(SpatialRefSystem{SRID: 4326, Name: "WGS 84", AxisOrder: AxisOrderLatLong})
+	return r
+}
+
+func (r *defaultSpatialRefSystemRegistry) Lookup(srid uint32) (SpatialRefSystem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	srs, ok := r.srss[srid]
+	if !ok {
+		return SpatialRefSystem{}, ErrUnknownSpatialRefSystem.New(srid)
+	}
+	return srs, nil
+}
+
+func (r *defaultSpatialRefSystemRegistry) Register(srs SpatialRefSystem) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.srss[srs.SRID] = srs
+}
@@ -0,0 +1,315 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"bufio"
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/snappy"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// gob requires every concrete type that's ever placed in a Row's
+// interface{} slots to be registered up front, even when that type has its
+// own GobEncode/GobDecode — without this, spilling a row holding anything
+// other than the handful of types gob pre-registers for itself (the basic
+// kinds, plus a few standard-library ones) fails with "gob: type not
+// registered for interface" the moment a spill actually happens. This list
+// must be kept in sync with the concrete value types sql.Type
+// implementations hand back from Convert/SQL.
+func init() {
+	gob.Register(time.Time{})
+	gob.Register(Geometry{})
+	gob.Register(Point{})
+	gob.Register(Linestring{})
+	gob.Register(Polygon{})
+	gob.Register(MultiPoint{})
+	gob.Register(MultiLinestring{})
+	gob.Register(MultiPolygon{})
+	gob.Register(GeometryCollection{})
+}
+
+// SpillWriter streams row batches to a single spill file. Rows written
+// through it aren't visible until it's been Closed, at which point its
+// Reader method returns something that plays them back in order. The file
+// survives any number of Reader calls and Closes of the readers they
+// return — it's only deleted by Remove, which the writer's owner calls once
+// it knows no further Reader call will be made (e.g. from
+// plan.CachedResults.Dispose).
+type SpillWriter interface {
+	// Write appends row to the spill file.
+	Write(row Row) error
+	// Close flushes and finalizes the spill file. It must be called
+	// exactly once, whether or not Write ever returned an error.
+	Close() error
+	// Reader returns a SpillReader over the rows written so far. It may be
+	// called any number of times after Close, each returning an
+	// independent reader starting from the first row.
+	Reader() (SpillReader, error)
+	// Remove deletes the underlying spill file. Safe to call more than
+	// once. Callers must not call Reader again afterward.
+	Remove() error
+}
+
+// SpillReader plays back the rows a SpillWriter wrote, in order. Close
+// releases the reader's own file handle, but — unlike the SpillWriter that
+// created it — does not delete the spill file itself, since the same
+// SpillWriter may still be asked for another Reader later.
+type SpillReader interface {
+	// Next returns the next row, or io.EOF once exhausted.
+	Next() (Row, error)
+	// Close releases this reader's file handle. Safe to call more than
+	// once. It does not delete the underlying spill file; see
+	// SpillWriter.Remove.
+	Close() error
+}
+
+// SpillMetrics reports cumulative spill activity for a SpillManager,
+// surfaced the same way other server-wide counters are (e.g. alongside
+// sql.QueryCacheStatus).
+type SpillMetrics struct {
+	// BytesSpilled is the number of uncompressed row bytes ever written to
+	// a spill file.
+	BytesSpilled int64
+	// BytesCompressed is the number of bytes those rows occupied on disk
+	// after Snappy compression (equal to BytesSpilled when compression is
+	// disabled).
+	BytesCompressed int64
+	// BytesRead is the number of uncompressed row bytes ever read back from
+	// a spill file.
+	BytesRead int64
+}
+
+// CompressionRatio returns BytesSpilled / BytesCompressed, or 1 if nothing
+// has been spilled yet.
+func (m SpillMetrics) CompressionRatio() float64 {
+	if m.BytesCompressed == 0 {
+		return 1
+	}
+	return float64(m.BytesSpilled) / float64(m.BytesCompressed)
+}
+
+// SpillManager creates the spill files that sort, hash-join, group-by and
+// CachedResults fall back to once their in-memory row batches exceed the
+// available memory budget, instead of aborting (as plan.CachedResults used
+// to do by setting its noCache flag) or running out of memory.
+type SpillManager interface {
+	// NewWriter returns a SpillWriter backed by a new temp file under the
+	// manager's configured directory.
+	NewWriter() (SpillWriter, error)
+	// Metrics returns the manager's cumulative SpillMetrics.
+	Metrics() SpillMetrics
+}
+
+// diskSpillManager is the default SpillManager: temp files under Directory,
+// each compressed with Snappy unless CompressionDisabled is set.
+type diskSpillManager struct {
+	directory          string
+	maxDiskBytes        int64
+	compressionDisabled bool
+
+	mu          sync.Mutex
+	diskBytesUsed int64
+
+	metrics SpillMetrics
+}
+
+var _ SpillManager = (*diskSpillManager)(nil)
+
+// NewDiskSpillManager returns a SpillManager that writes spill files under
+// directory, refusing new writers once maxDiskBytes worth of spill files
+// have been closed over the manager's lifetime (0 means unlimited), and
+// compressing them with Snappy unless compressionDisabled is set. Files
+// deleted with Remove still count toward maxDiskBytes.
+func NewDiskSpillManager(directory string, maxDiskBytes int64, compressionDisabled bool) SpillManager {
+	return &diskSpillManager{
+		directory:           directory,
+		maxDiskBytes:        maxDiskBytes,
+		compressionDisabled: compressionDisabled,
+	}
+}
+
+var ErrSpillDiskQuotaExceeded = errors.NewKind("spill disk quota exceeded: %d bytes spilled, limit is %d")
+
+func (m *diskSpillManager) NewWriter() (SpillWriter, error) {
+	m.mu.Lock()
+	overQuota := m.maxDiskBytes > 0 && m.diskBytesUsed >= m.maxDiskBytes
+	m.mu.Unlock()
+	if overQuota {
+		return nil, ErrSpillDiskQuotaExceeded.New(m.diskBytesUsed, m.maxDiskBytes)
+	}
+
+	f, err := ioutil.TempFile(m.directory, "gms-spill-*.bin")
+	if err != nil {
+		return nil, err
+	}
+
+	return &diskSpillWriter{mgr: m, file: f}, nil
+}
+
+func (m *diskSpillManager) Metrics() SpillMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.metrics
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+type diskSpillWriter struct {
+	mgr  *diskSpillManager
+	file *os.File
+
+	raw     *countingWriter
+	encoder *gob.Encoder
+	closer  io.Closer
+
+	uncompressedBytes int64
+}
+
+func (w *diskSpillWriter) ensureOpen() {
+	if w.encoder != nil {
+		return
+	}
+	var out io.WriteCloser
+	if w.mgr.compressionDisabled {
+		bw := bufio.NewWriter(w.file)
+		out = nopWriteCloser{bw}
+		w.closer = flusherCloser{bw}
+	} else {
+		sw := snappy.NewBufferedWriter(w.file)
+		out = sw
+		w.closer = sw
+	}
+	// Count bytes before compression, so BytesSpilled is the uncompressed size.
+	w.raw = &countingWriter{w: out}
+	w.encoder = gob.NewEncoder(w.raw)
+}
+
+func (w *diskSpillWriter) Write(row Row) error {
+	w.ensureOpen()
+	if err := w.encoder.Encode(row); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *diskSpillWriter) Close() error {
+	w.ensureOpen()
+	if w.closer != nil {
+		if err := w.closer.Close(); err != nil {
+			return err
+		}
+	}
+
+	info, err := w.file.Stat()
+	if err == nil {
+		compressed := info.Size()
+		w.mgr.mu.Lock()
+		w.mgr.diskBytesUsed += compressed
+		w.mgr.metrics.BytesCompressed += compressed
+		w.mgr.metrics.BytesSpilled += atomic.LoadInt64(&w.raw.n)
+		w.mgr.mu.Unlock()
+	}
+
+	return w.file.Close()
+}
+
+func (w *diskSpillWriter) Reader() (SpillReader, error) {
+	f, err := os.Open(w.file.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	var in io.Reader = f
+	if !w.mgr.compressionDisabled {
+		in = snappy.NewReader(f)
+	}
+	counting := &countingReader{r: in}
+
+	return &diskSpillReader{mgr: w.mgr, file: f, counting: counting, decoder: gob.NewDecoder(counting)}, nil
+}
+
+// Remove deletes the spill file. Safe to call more than once.
+func (w *diskSpillWriter) Remove() error {
+	err := os.Remove(w.file.Name())
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+type diskSpillReader struct {
+	mgr      *diskSpillManager
+	file     *os.File
+	counting *countingReader
+	decoder  *gob.Decoder
+	closed   bool
+}
+
+func (r *diskSpillReader) Next() (Row, error) {
+	before := atomic.LoadInt64(&r.counting.n)
+	var row Row
+	if err := r.decoder.Decode(&row); err != nil {
+		return nil, err
+	}
+	after := atomic.LoadInt64(&r.counting.n)
+	r.mgr.mu.Lock()
+	r.mgr.metrics.BytesRead += after - before
+	r.mgr.mu.Unlock()
+	return row, nil
+}
+
+func (r *diskSpillReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.file.Close()
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+type flusherCloser struct{ w *bufio.Writer }
+
+func (f flusherCloser) Close() error { return f.w.Flush() }
@@ -0,0 +1,30 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// VersionedTable is implemented by tables that can report a commit/version
+// identifier for their current state, the same notion of "version" that
+// UnresolvedTable.WithAsOf pins a query to. Materialized view freshness is
+// checked by comparing the CurrentVersion of each source table against the
+// version recorded at the view's last refresh.
+type VersionedTable interface {
+	Table
+	// CurrentVersion returns an opaque string identifying this table's
+	// current state. Two calls return the same string if and only if the
+	// table has not changed in between, for tables that support it;
+	// tables with no versioning concept should not implement this
+	// interface at all rather than returning a constant.
+	CurrentVersion(ctx *Context) (string, error)
+}
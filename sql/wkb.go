@@ -0,0 +1,326 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/binary"
+	"math"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidWKB is returned when a byte string passed to ST_GeomFromWKB is
+// not a well-formed Well-Known Binary geometry.
+var ErrInvalidWKB = errors.NewKind("invalid WKB geometry")
+
+// WKB geometry type codes, as defined by the OGC Simple Features spec.
+const (
+	wkbPointType              = 1
+	wkbLinestringType         = 2
+	wkbPolygonType            = 3
+	wkbMultiPointType         = 4
+	wkbMultiLinestringType    = 5
+	wkbMultiPolygonType       = 6
+	wkbGeometryCollectionType = 7
+)
+
+// WKBEncode serializes g as little-endian Well-Known Binary, the same
+// format produced by MySQL's ST_AsWKB. SRID is not part of the WKB format
+// itself, matching ST_AsWKB's behavior of returning the geometry alone.
+func WKBEncode(g GeometryValue) []byte {
+	buf := make([]byte, 0, 64)
+	return appendWKB(buf, g)
+}
+
+func appendWKB(buf []byte, g GeometryValue) []byte {
+	switch g := g.(type) {
+	case Point:
+		buf = appendWKBHeader(buf, wkbPointType)
+		return appendWKBPoint(buf, g)
+	case Linestring:
+		buf = appendWKBHeader(buf, wkbLinestringType)
+		return appendWKBLinestring(buf, g)
+	case Polygon:
+		buf = appendWKBHeader(buf, wkbPolygonType)
+		return appendWKBPolygon(buf, g)
+	case MultiPoint:
+		buf = appendWKBHeader(buf, wkbMultiPointType)
+		buf = appendUint32(buf, uint32(len(g.Points)))
+		for _, p := range g.Points {
+			buf = appendWKBHeader(buf, wkbPointType)
+			buf = appendWKBPoint(buf, p)
+		}
+		return buf
+	case MultiLinestring:
+		buf = appendWKBHeader(buf, wkbMultiLinestringType)
+		buf = appendUint32(buf, uint32(len(g.Lines)))
+		for _, l := range g.Lines {
+			buf = appendWKBHeader(buf, wkbLinestringType)
+			buf = appendWKBLinestring(buf, l)
+		}
+		return buf
+	case MultiPolygon:
+		buf = appendWKBHeader(buf, wkbMultiPolygonType)
+		buf = appendUint32(buf, uint32(len(g.Polygons)))
+		for _, p := range g.Polygons {
+			buf = appendWKBHeader(buf, wkbPolygonType)
+			buf = appendWKBPolygon(buf, p)
+		}
+		return buf
+	case GeometryCollection:
+		buf = appendWKBHeader(buf, wkbGeometryCollectionType)
+		buf = appendUint32(buf, uint32(len(g.Geoms)))
+		for _, inner := range g.Geoms {
+			buf = appendWKB(buf, inner)
+		}
+		return buf
+	case Geometry:
+		return appendWKB(buf, g.Inner)
+	default:
+		return buf
+	}
+}
+
+func appendWKBHeader(buf []byte, typ uint32) []byte {
+	buf = append(buf, 1) // little-endian byte order marker
+	return appendUint32(buf, typ)
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func appendFloat64(buf []byte, v float64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(v))
+	return append(buf, tmp[:]...)
+}
+
+func appendWKBPoint(buf []byte, p Point) []byte {
+	buf = appendFloat64(buf, p.X)
+	return appendFloat64(buf, p.Y)
+}
+
+func appendWKBLinestring(buf []byte, l Linestring) []byte {
+	buf = appendUint32(buf, uint32(len(l.Points)))
+	for _, p := range l.Points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+func appendWKBPolygon(buf []byte, p Polygon) []byte {
+	buf = appendUint32(buf, uint32(len(p.Lines)))
+	for _, l := range p.Lines {
+		buf = appendWKBLinestring(buf, l)
+	}
+	return buf
+}
+
+// WKBDecode parses Well-Known Binary produced by WKBEncode (or MySQL's
+// ST_AsWKB) back into a GeometryValue. The returned value's SRID is always
+// 0; callers that need to associate a SRID (e.g. ST_GeomFromWKB's optional
+// second argument) should call SetSRID on the result.
+func WKBDecode(data []byte) (GeometryValue, error) {
+	g, rest, err := decodeWKB(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, ErrInvalidWKB.New()
+	}
+	return g, nil
+}
+
+// checkWKBCount guards against a maliciously declared element count: n
+// comes straight from untrusted WKB bytes and is used as a make() length
+// before the buffer backing each element has been read, so without this
+// check a tiny payload claiming billions of elements forces a multi-GB
+// allocation before decoding ever validates the data is actually that long.
+// minElemSize is the fewest bytes every kind of element must occupy (16 for
+// a Point, 5 for the byte-order+type header of a nested geometry, ...), so
+// this rejects n before len(data) could possibly hold it.
+func checkWKBCount(n uint32, data []byte, minElemSize int) error {
+	if uint64(n) > uint64(len(data))/uint64(minElemSize) {
+		return ErrInvalidWKB.New()
+	}
+	return nil
+}
+
+func decodeWKB(data []byte) (GeometryValue, []byte, error) {
+	if len(data) < 5 {
+		return nil, nil, ErrInvalidWKB.New()
+	}
+	if data[0] != 1 {
+		return nil, nil, ErrInvalidWKB.New()
+	}
+	typ := binary.LittleEndian.Uint32(data[1:5])
+	data = data[5:]
+
+	switch typ {
+	case wkbPointType:
+		p, rest, err := decodeWKBPoint(data)
+		return p, rest, err
+	case wkbLinestringType:
+		l, rest, err := decodeWKBLinestring(data)
+		return l, rest, err
+	case wkbPolygonType:
+		p, rest, err := decodeWKBPolygon(data)
+		return p, rest, err
+	case wkbMultiPointType:
+		if len(data) < 4 {
+			return nil, nil, ErrInvalidWKB.New()
+		}
+		n := binary.LittleEndian.Uint32(data[:4])
+		data = data[4:]
+		if err := checkWKBCount(n, data, 5); err != nil {
+			return nil, nil, err
+		}
+		points := make([]Point, n)
+		for i := uint32(0); i < n; i++ {
+			g, rest, err := decodeWKB(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			p, ok := g.(Point)
+			if !ok {
+				return nil, nil, ErrInvalidWKB.New()
+			}
+			points[i] = p
+			data = rest
+		}
+		return MultiPoint{Points: points}, data, nil
+	case wkbMultiLinestringType:
+		if len(data) < 4 {
+			return nil, nil, ErrInvalidWKB.New()
+		}
+		n := binary.LittleEndian.Uint32(data[:4])
+		data = data[4:]
+		if err := checkWKBCount(n, data, 5); err != nil {
+			return nil, nil, err
+		}
+		lines := make([]Linestring, n)
+		for i := uint32(0); i < n; i++ {
+			g, rest, err := decodeWKB(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			l, ok := g.(Linestring)
+			if !ok {
+				return nil, nil, ErrInvalidWKB.New()
+			}
+			lines[i] = l
+			data = rest
+		}
+		return MultiLinestring{Lines: lines}, data, nil
+	case wkbMultiPolygonType:
+		if len(data) < 4 {
+			return nil, nil, ErrInvalidWKB.New()
+		}
+		n := binary.LittleEndian.Uint32(data[:4])
+		data = data[4:]
+		if err := checkWKBCount(n, data, 5); err != nil {
+			return nil, nil, err
+		}
+		polys := make([]Polygon, n)
+		for i := uint32(0); i < n; i++ {
+			g, rest, err := decodeWKB(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			p, ok := g.(Polygon)
+			if !ok {
+				return nil, nil, ErrInvalidWKB.New()
+			}
+			polys[i] = p
+			data = rest
+		}
+		return MultiPolygon{Polygons: polys}, data, nil
+	case wkbGeometryCollectionType:
+		if len(data) < 4 {
+			return nil, nil, ErrInvalidWKB.New()
+		}
+		n := binary.LittleEndian.Uint32(data[:4])
+		data = data[4:]
+		if err := checkWKBCount(n, data, 5); err != nil {
+			return nil, nil, err
+		}
+		geoms := make([]GeometryValue, n)
+		for i := uint32(0); i < n; i++ {
+			g, rest, err := decodeWKB(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			geoms[i] = g
+			data = rest
+		}
+		return GeometryCollection{Geoms: geoms}, data, nil
+	default:
+		return nil, nil, ErrInvalidWKB.New()
+	}
+}
+
+func decodeWKBPoint(data []byte) (Point, []byte, error) {
+	if len(data) < 16 {
+		return Point{}, nil, ErrInvalidWKB.New()
+	}
+	x := math.Float64frombits(binary.LittleEndian.Uint64(data[0:8]))
+	y := math.Float64frombits(binary.LittleEndian.Uint64(data[8:16]))
+	return Point{X: x, Y: y}, data[16:], nil
+}
+
+func decodeWKBLinestring(data []byte) (Linestring, []byte, error) {
+	if len(data) < 4 {
+		return Linestring{}, nil, ErrInvalidWKB.New()
+	}
+	n := binary.LittleEndian.Uint32(data[:4])
+	data = data[4:]
+	if err := checkWKBCount(n, data, 16); err != nil {
+		return Linestring{}, nil, err
+	}
+	points := make([]Point, n)
+	for i := uint32(0); i < n; i++ {
+		p, rest, err := decodeWKBPoint(data)
+		if err != nil {
+			return Linestring{}, nil, err
+		}
+		points[i] = p
+		data = rest
+	}
+	return Linestring{Points: points}, data, nil
+}
+
+func decodeWKBPolygon(data []byte) (Polygon, []byte, error) {
+	if len(data) < 4 {
+		return Polygon{}, nil, ErrInvalidWKB.New()
+	}
+	n := binary.LittleEndian.Uint32(data[:4])
+	data = data[4:]
+	if err := checkWKBCount(n, data, 4); err != nil {
+		return Polygon{}, nil, err
+	}
+	lines := make([]Linestring, n)
+	for i := uint32(0); i < n; i++ {
+		l, rest, err := decodeWKBLinestring(data)
+		if err != nil {
+			return Polygon{}, nil, err
+		}
+		lines[i] = l
+		data = rest
+	}
+	return Polygon{Lines: lines}, data, nil
+}
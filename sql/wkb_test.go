@@ -0,0 +1,69 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWKBRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		geom GeometryValue
+	}{
+		{"point", Point{X: 1, Y: 2}},
+		{"linestring", Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"polygon", Polygon{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}},
+		}}},
+		{"multipoint", MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+		{"multilinestring", MultiLinestring{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []Point{{X: 2, Y: 2}, {X: 3, Y: 3}}},
+		}}},
+		{"multipolygon", MultiPolygon{Polygons: []Polygon{
+			{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+		}}},
+		{"geometrycollection", GeometryCollection{Geoms: []GeometryValue{
+			Point{X: 1, Y: 1},
+			Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			data := WKBEncode(test.geom)
+			got, err := WKBDecode(data)
+			require.NoError(err)
+			require.Equal(test.geom, got)
+		})
+	}
+}
+
+func TestWKBDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"too short", []byte{1, 0, 0, 0}},
+		{"bad byte order marker", []byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{"unknown type code", []byte{1, 99, 0, 0, 0}},
+		{"truncated point", append([]byte{1, 1, 0, 0, 0}, make([]byte, 4)...)},
+		{"trailing garbage", append(WKBEncode(Point{X: 1, Y: 2}), 0xFF)},
+		// A tiny payload declaring a ~4 billion element MultiPoint must be
+		// rejected before the declared count is used to allocate, or this
+		// becomes a multi-GB allocation from a few bytes of SQL input.
+		{"oversized declared count", []byte{1, 4, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			_, err := WKBDecode(test.data)
+			require.Error(err)
+			require.True(ErrInvalidWKB.Is(err))
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidWKT is returned when a string passed to ST_GeomFromText is not a
+// well-formed Well-Known Text geometry.
+var ErrInvalidWKT = errors.NewKind("invalid WKT geometry: %s")
+
+// WKTEncode serializes g as Well-Known Text, the format produced by MySQL's
+// ST_AsText.
+func WKTEncode(g GeometryValue) string {
+	switch g := g.(type) {
+	case Point:
+		return "POINT(" + wktPoint(g) + ")"
+	case Linestring:
+		return "LINESTRING(" + wktPointList(g.Points) + ")"
+	case Polygon:
+		return "POLYGON(" + wktLineList(g.Lines) + ")"
+	case MultiPoint:
+		parts := make([]string, len(g.Points))
+		for i, p := range g.Points {
+			parts[i] = wktPoint(p)
+		}
+		return "MULTIPOINT(" + strings.Join(parts, ",") + ")"
+	case MultiLinestring:
+		parts := make([]string, len(g.Lines))
+		for i, l := range g.Lines {
+			parts[i] = "(" + wktPointList(l.Points) + ")"
+		}
+		return "MULTILINESTRING(" + strings.Join(parts, ",") + ")"
+	case MultiPolygon:
+		parts := make([]string, len(g.Polygons))
+		for i, p := range g.Polygons {
+			parts[i] = "(" + wktLineList(p.Lines) + ")"
+		}
+		return "MULTIPOLYGON(" + strings.Join(parts, ",") + ")"
+	case GeometryCollection:
+		parts := make([]string, len(g.Geoms))
+		for i, inner := range g.Geoms {
+			parts[i] = WKTEncode(inner)
+		}
+		return "GEOMETRYCOLLECTION(" + strings.Join(parts, ",") + ")"
+	case Geometry:
+		return WKTEncode(g.Inner)
+	default:
+		return ""
+	}
+}
+
+func wktPoint(p Point) string {
+	return strconv.FormatFloat(p.X, 'g', -1, 64) + " " + strconv.FormatFloat(p.Y, 'g', -1, 64)
+}
+
+func wktPointList(points []Point) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = wktPoint(p)
+	}
+	return strings.Join(parts, ",")
+}
+
+func wktLineList(lines []Linestring) string {
+	parts := make([]string, len(lines))
+	for i, l := range lines {
+		parts[i] = "(" + wktPointList(l.Points) + ")"
+	}
+	return strings.Join(parts, ",")
+}
+
+// WKTDecode parses Well-Known Text back into a GeometryValue. It accepts the
+// same subset of the grammar that WKTEncode produces: a type keyword
+// followed by a fully parenthesized coordinate list. Whitespace around the
+// keyword, parentheses and commas is optional; the two coordinates of a
+// point must be separated by whitespace.
+func WKTDecode(s string) (GeometryValue, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+
+	parseBody := func(keyword string) (string, bool) {
+		if !strings.HasPrefix(upper, keyword) {
+			return "", false
+		}
+		body := strings.TrimSpace(s[len(keyword):])
+		if !strings.HasPrefix(body, "(") || !strings.HasSuffix(body, ")") {
+			return "", false
+		}
+		return body[1 : len(body)-1], true
+	}
+
+	switch {
+	case strings.HasPrefix(upper, "POINT"):
+		body, ok := parseBody("POINT")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		p, err := parseWKTPoint(body)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case strings.HasPrefix(upper, "LINESTRING"):
+		body, ok := parseBody("LINESTRING")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		points, err := parseWKTPointList(body)
+		if err != nil {
+			return nil, err
+		}
+		return Linestring{Points: points}, nil
+	case strings.HasPrefix(upper, "POLYGON"):
+		body, ok := parseBody("POLYGON")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		lines, err := parseWKTLineList(body)
+		if err != nil {
+			return nil, err
+		}
+		return Polygon{Lines: lines}, nil
+	case strings.HasPrefix(upper, "MULTIPOINT"):
+		body, ok := parseBody("MULTIPOINT")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		points, err := parseWKTPointList(strings.NewReplacer("(", "", ")", "").Replace(body))
+		if err != nil {
+			return nil, err
+		}
+		return MultiPoint{Points: points}, nil
+	case strings.HasPrefix(upper, "MULTILINESTRING"):
+		body, ok := parseBody("MULTILINESTRING")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		groups, err := splitTopLevelGroups(body)
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]Linestring, len(groups))
+		for i, grp := range groups {
+			points, err := parseWKTPointList(grp)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = Linestring{Points: points}
+		}
+		return MultiLinestring{Lines: lines}, nil
+	case strings.HasPrefix(upper, "MULTIPOLYGON"):
+		body, ok := parseBody("MULTIPOLYGON")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		groups, err := splitTopLevelGroups(body)
+		if err != nil {
+			return nil, err
+		}
+		polys := make([]Polygon, len(groups))
+		for i, grp := range groups {
+			innerGroups, err := splitTopLevelGroups(grp)
+			if err != nil {
+				return nil, err
+			}
+			lines := make([]Linestring, len(innerGroups))
+			for j, ig := range innerGroups {
+				points, err := parseWKTPointList(ig)
+				if err != nil {
+					return nil, err
+				}
+				lines[j] = Linestring{Points: points}
+			}
+			polys[i] = Polygon{Lines: lines}
+		}
+		return MultiPolygon{Polygons: polys}, nil
+	case strings.HasPrefix(upper, "GEOMETRYCOLLECTION"):
+		body, ok := parseBody("GEOMETRYCOLLECTION")
+		if !ok {
+			return nil, ErrInvalidWKT.New(s)
+		}
+		if strings.TrimSpace(body) == "" {
+			return GeometryCollection{}, nil
+		}
+		groups, err := splitTopLevelGeometries(body)
+		if err != nil {
+			return nil, err
+		}
+		geoms := make([]GeometryValue, len(groups))
+		for i, grp := range groups {
+			g, err := WKTDecode(grp)
+			if err != nil {
+				return nil, err
+			}
+			geoms[i] = g
+		}
+		return GeometryCollection{Geoms: geoms}, nil
+	default:
+		return nil, ErrInvalidWKT.New(s)
+	}
+}
+
+func parseWKTPoint(s string) (Point, error) {
+	fields := strings.Fields(strings.TrimSpace(s))
+	if len(fields) != 2 {
+		return Point{}, ErrInvalidWKT.New(s)
+	}
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Point{}, ErrInvalidWKT.New(s)
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Point{}, ErrInvalidWKT.New(s)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func parseWKTPointList(s string) ([]Point, error) {
+	raw := strings.Split(s, ",")
+	points := make([]Point, len(raw))
+	for i, r := range raw {
+		p, err := parseWKTPoint(r)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+func parseWKTLineList(s string) ([]Linestring, error) {
+	groups, err := splitTopLevelGroups(s)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]Linestring, len(groups))
+	for i, grp := range groups {
+		points, err := parseWKTPointList(grp)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = Linestring{Points: points}
+	}
+	return lines, nil
+}
+
+// splitTopLevelGroups splits a comma-separated list of "(...)" groups into
+// their interiors, respecting parenthesis nesting.
+func splitTopLevelGroups(s string) ([]string, error) {
+	var groups []string
+	depth := 0
+	start := -1
+	for i, r := range s {
+		switch r {
+		case '(':
+			if depth == 0 {
+				start = i + 1
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				groups = append(groups, s[start:i])
+			} else if depth < 0 {
+				return nil, ErrInvalidWKT.New(s)
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, ErrInvalidWKT.New(s)
+	}
+	return groups, nil
+}
+
+// splitTopLevelGeometries splits a comma-separated list of nested geometry
+// literals (e.g. the body of a GEOMETRYCOLLECTION), respecting parenthesis
+// nesting so commas inside a nested geometry's own coordinate list don't
+// split it.
+func splitTopLevelGeometries(s string) ([]string, error) {
+	var parts []string
+	depth := 0
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, ErrInvalidWKT.New(s)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, ErrInvalidWKT.New(s)
+	}
+	parts = append(parts, strings.TrimSpace(s[start:]))
+	return parts, nil
+}
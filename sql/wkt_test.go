@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWKTRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		geom GeometryValue
+	}{
+		{"point", Point{X: 1, Y: 2}},
+		{"linestring", Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"polygon", Polygon{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}},
+		}}},
+		{"multipoint", MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}},
+		{"multilinestring", MultiLinestring{Lines: []Linestring{
+			{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			{Points: []Point{{X: 2, Y: 2}, {X: 3, Y: 3}}},
+		}}},
+		{"multipolygon", MultiPolygon{Polygons: []Polygon{
+			{Lines: []Linestring{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+		}}},
+		{"geometrycollection", GeometryCollection{Geoms: []GeometryValue{
+			Point{X: 1, Y: 1},
+			Linestring{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			text := WKTEncode(test.geom)
+			got, err := WKTDecode(text)
+			require.NoError(err)
+			require.Equal(test.geom, got)
+		})
+	}
+}
+
+func TestWKTDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"empty", ""},
+		{"unknown keyword", "CIRCLE(0 0)"},
+		{"missing parens", "POINT 0 0"},
+		{"unbalanced parens", "POLYGON((0 0,0 1,1 1,0 0)"},
+		{"non-numeric coordinate", "POINT(a b)"},
+		{"wrong coordinate count", "POINT(1 2 3)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			_, err := WKTDecode(test.text)
+			require.Error(err)
+			require.True(ErrInvalidWKT.Is(err))
+		})
+	}
+}